@@ -0,0 +1,274 @@
+package backlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ActivityType is the type of activities and webhook events.
+type ActivityType int
+
+// ActivityType
+const (
+	ActivityTypeIssueCreated ActivityType = iota + 1
+	ActivityTypeIssueUpdated
+	ActivityTypeIssueCommented
+	ActivityTypeIssueDeleted
+	ActivityTypeWikiCreated
+	ActivityTypeWikiUpdated
+	ActivityTypeWikiDeleted
+	ActivityTypeFileAdded
+	ActivityTypeFileUpdated
+	ActivityTypeFileDeleted
+	ActivityTypeSVNCommitted
+	ActivityTypeGitPushed
+	ActivityTypeGitRepositoryCreated
+	ActivityTypeIssueMultiUpdated
+	ActivityTypeProjectUserAdded
+	ActivityTypeProjectUserRemoved
+	ActivityTypeCommentNotificationAdded
+	ActivityTypePullRequestAdded
+	ActivityTypePullRequestUpdated
+	ActivityTypePullRequestCommented
+	ActivityTypePullRequestDeleted
+	ActivityTypeMilestoneCreated
+	ActivityTypeMilestoneUpdated
+	ActivityTypeMilestoneDeleted
+	ActivityTypeProjectGroupAdded
+	ActivityTypeProjectGroupDeleted
+)
+
+// isIssue reports whether the content of activities of type t is an issue.
+func (t ActivityType) isIssue() bool {
+	switch t {
+	case ActivityTypeIssueCreated, ActivityTypeIssueUpdated, ActivityTypeIssueCommented, ActivityTypeIssueDeleted,
+		ActivityTypeIssueMultiUpdated, ActivityTypeCommentNotificationAdded:
+		return true
+	}
+	return false
+}
+
+// Activity is a recent update in the space.
+// Content is one of the *ActivityContent types according to Type.
+type Activity struct {
+	ID          int             `json:"id"`
+	Project     *Project        `json:"project"`
+	Type        ActivityType    `json:"type"`
+	Content     ActivityContent `json:"content"`
+	CreatedUser *User           `json:"createdUser"`
+	Created     time.Time       `json:"created"`
+}
+
+// ActivityContent is the content of an Activity.
+type ActivityContent interface{}
+
+// ActivityChange is a changed field of an issue, a pull request or a milestone.
+type ActivityChange struct {
+	Field    string `json:"field"`
+	NewValue string `json:"new_value"`
+	OldValue string `json:"old_value"`
+	Type     string `json:"type"`
+}
+
+// IssueActivityContent is the content of issue activities.
+type IssueActivityContent struct {
+	ID          int    `json:"id"`
+	KeyID       int    `json:"key_id"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	Comment     *struct {
+		ID      int    `json:"id"`
+		Content string `json:"content"`
+	} `json:"comment"`
+	Changes []*ActivityChange `json:"changes"`
+}
+
+// WikiActivityContent is the content of wiki activities.
+type WikiActivityContent struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	Diff    string `json:"diff"`
+}
+
+// FileActivityContent is the content of shared file activities.
+type FileActivityContent struct {
+	ID        int    `json:"id"`
+	Directory string `json:"dir"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+}
+
+// GitActivityRepository is the git repository of git and pull request activities.
+type GitActivityRepository struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GitPushActivityContent is the content of git push and git repository activities.
+type GitPushActivityContent struct {
+	Repository    *GitActivityRepository `json:"repository"`
+	ChangeType    string                 `json:"change_type"`
+	RevisionType  string                 `json:"revision_type"`
+	Ref           string                 `json:"ref"`
+	RevisionCount int                    `json:"revision_count"`
+	Revisions     []*struct {
+		Rev     string `json:"rev"`
+		Comment string `json:"comment"`
+	} `json:"revisions"`
+}
+
+// PullRequestActivityContent is the content of pull request activities.
+type PullRequestActivityContent struct {
+	ID          int    `json:"id"`
+	Number      int    `json:"number"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	Comment     *struct {
+		ID      int    `json:"id"`
+		Content string `json:"content"`
+	} `json:"comment"`
+	Changes    []*ActivityChange      `json:"changes"`
+	Repository *GitActivityRepository `json:"repository"`
+}
+
+// MilestoneActivityContent is the content of milestone activities.
+type MilestoneActivityContent struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	StartDate     string            `json:"start_date"`
+	ReferenceDate string            `json:"reference_date"`
+	Description   string            `json:"description"`
+	Changes       []*ActivityChange `json:"changes"`
+}
+
+// RawActivityContent is the content of the other activities as it is.
+type RawActivityContent json.RawMessage
+
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID          int             `json:"id"`
+		Project     *Project        `json:"project"`
+		Type        ActivityType    `json:"type"`
+		Content     json.RawMessage `json:"content"`
+		CreatedUser *User           `json:"createdUser"`
+		Created     time.Time       `json:"created"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	content, err := decodeActivityContent(raw.Type, raw.Content)
+	if err != nil {
+		return fmt.Errorf("decode content of activity %d (type %d) failed: %v", raw.ID, raw.Type, err)
+	}
+
+	*a = Activity{
+		ID:          raw.ID,
+		Project:     raw.Project,
+		Type:        raw.Type,
+		Content:     content,
+		CreatedUser: raw.CreatedUser,
+		Created:     raw.Created,
+	}
+
+	return nil
+}
+
+// decodeActivityContent decodes data into the content type of activities of type t.
+func decodeActivityContent(t ActivityType, data json.RawMessage) (ActivityContent, error) {
+	var c ActivityContent
+
+	switch t {
+	case ActivityTypeIssueCreated, ActivityTypeIssueUpdated, ActivityTypeIssueCommented, ActivityTypeIssueDeleted, ActivityTypeCommentNotificationAdded:
+		c = &IssueActivityContent{}
+	case ActivityTypeWikiCreated, ActivityTypeWikiUpdated, ActivityTypeWikiDeleted:
+		c = &WikiActivityContent{}
+	case ActivityTypeFileAdded, ActivityTypeFileUpdated, ActivityTypeFileDeleted:
+		c = &FileActivityContent{}
+	case ActivityTypeGitPushed, ActivityTypeGitRepositoryCreated:
+		c = &GitPushActivityContent{}
+	case ActivityTypePullRequestAdded, ActivityTypePullRequestUpdated, ActivityTypePullRequestCommented, ActivityTypePullRequestDeleted:
+		c = &PullRequestActivityContent{}
+	case ActivityTypeMilestoneCreated, ActivityTypeMilestoneUpdated, ActivityTypeMilestoneDeleted:
+		c = &MilestoneActivityContent{}
+	default:
+		return RawActivityContent(data), nil
+	}
+
+	if len(data) == 0 {
+		return c, nil
+	}
+	err := json.Unmarshal(data, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+//+ActivityQuery
+
+type ActivityQuery url.Values
+
+func NewActivityQuery() ActivityQuery {
+	q := ActivityQuery{}
+	return q
+}
+
+func (q ActivityQuery) SetActivityType(t ActivityType) {
+	url.Values(q).Add("activityTypeId[]", strconv.Itoa(int(t)))
+	return
+}
+
+func (q ActivityQuery) SetMinID(id int) {
+	url.Values(q).Set("minId", strconv.Itoa(id))
+	return
+}
+
+func (q ActivityQuery) SetMaxID(id int) {
+	url.Values(q).Set("maxId", strconv.Itoa(id))
+	return
+}
+
+// SetCount sets the number of activities to list, 1 to 100.
+func (q ActivityQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+// SetOrder sets "asc" or "desc".
+func (q ActivityQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+//-ActivityQuery
+
+func (repo *Repository) listActivities(path string, q ActivityQuery) ([]*Activity, error) {
+	var as []*Activity
+	err := repo.get(path, url.Values(q), &as)
+	if err != nil {
+		return nil, err
+	}
+
+	return as, nil
+}
+
+func (repo *Repository) ListSpaceActivities(q ActivityQuery) ([]*Activity, error) {
+	return repo.listActivities("api/v2/space/activities", q)
+}
+
+func (repo *Repository) ListProjectActivities(projectIDOrKey string, q ActivityQuery) ([]*Activity, error) {
+	return repo.listActivities(fmt.Sprintf("api/v2/projects/%s/activities", projectIDOrKey), q)
+}
+
+func (repo *Repository) ListUserActivities(userID int, q ActivityQuery) ([]*Activity, error) {
+	return repo.listActivities(fmt.Sprintf("api/v2/users/%d/activities", userID), q)
+}
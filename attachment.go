@@ -0,0 +1,78 @@
+package backlog
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Attachment struct {
+	ID          int       `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	CreatedUser *User     `json:"createdUser,omitempty"`
+	Created     time.Time `json:"created,omitempty"`
+}
+
+// UploadAttachment uploads a file to the space.
+// Pass the ID of the returned Attachment to AttachToIssue, AttachToWiki,
+// AddComment or CreatePullRequest to attach it.
+func (repo *Repository) UploadAttachment(name string, r io.Reader) (*Attachment, error) {
+	var a Attachment
+	err := repo.upload("api/v2/space/attachment", &Upload{Name: name, Reader: r}, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// AttachToIssue attaches uploaded files to an issue.
+func (repo *Repository) AttachToIssue(issueIDOrKey string, attachmentIDs []int) (*Issue, error) {
+	params := url.Values{}
+	for _, id := range attachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("api/v2/issues/%s", issueIDOrKey)
+
+	var issue Issue
+	err := repo.patch(url, params, &issue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+func (repo *Repository) ListIssueAttachments(issueIDOrKey string) ([]*Attachment, error) {
+	url := fmt.Sprintf("api/v2/issues/%s/attachments", issueIDOrKey)
+
+	var as []*Attachment
+	err := repo.get(url, nil, &as)
+	if err != nil {
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// DownloadIssueAttachment downloads a file attached to an issue.
+// Close the returned File after reading.
+func (repo *Repository) DownloadIssueAttachment(issueIDOrKey string, id int) (*File, error) {
+	url := fmt.Sprintf("api/v2/issues/%s/attachments/%d", issueIDOrKey, id)
+	return repo.download(url, nil)
+}
+
+func (repo *Repository) DeleteIssueAttachment(issueIDOrKey string, id int) (*Attachment, error) {
+	url := fmt.Sprintf("api/v2/issues/%s/attachments/%d", issueIDOrKey, id)
+
+	var deleted Attachment
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
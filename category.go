@@ -0,0 +1,75 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type Category struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	DisplayOrder int    `json:"displayOrder,omitempty"`
+}
+
+type categories []*Category
+
+// findID returns the ID of the category named name.
+func (cs categories) findID(name string) (int, error) {
+	for _, c := range cs {
+		if c.Name == name {
+			return c.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("category '%s' is not found", name)
+}
+
+func (repo *Repository) ListCategories(projectIDOrKey string) ([]*Category, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/categories", projectIDOrKey)
+
+	var cs []*Category
+	err := repo.get(url, nil, &cs)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs, nil
+}
+
+func (repo *Repository) CreateCategory(projectIDOrKey string, name string) (*Category, error) {
+	params := url.Values{"name": {name}}
+	url := fmt.Sprintf("api/v2/projects/%s/categories", projectIDOrKey)
+
+	var created Category
+	err := repo.post(url, params, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateCategory renames the category with the same ID as category.
+func (repo *Repository) UpdateCategory(projectIDOrKey string, category *Category) (*Category, error) {
+	params := url.Values{"name": {category.Name}}
+	url := fmt.Sprintf("api/v2/projects/%s/categories/%d", projectIDOrKey, category.ID)
+
+	var updated Category
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (repo *Repository) DeleteCategory(projectIDOrKey string, id int) (*Category, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/categories/%d", projectIDOrKey, id)
+
+	var deleted Category
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
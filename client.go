@@ -1,20 +1,58 @@
 package backlog
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 )
 
+var apiKeyPattern = regexp.MustCompile(`(apiKey=)[^&\s"]*`)
+
+// Client sends a request to the Backlog API.
+// Implement it to inject fakes or recorders with NewRepositoryWithClient.
 type Client interface {
-	get(path string, params url.Values) ([]byte, error)
-	put(path string, params url.Values) ([]byte, error)
-	// post(path string, body []byte) ([]byte, error)
-	// put(path string, body []byte) ([]byte, error)
-	// delete(path string, body []byte) ([]byte, error)
+	Do(req *Request) (*Response, error)
+}
+
+// ClientFunc adapts an ordinary function to the Client interface.
+type ClientFunc func(req *Request) (*Response, error)
+
+// Do calls f(req).
+func (f ClientFunc) Do(req *Request) (*Response, error) {
+	return f(req)
+}
+
+// Request represents a Backlog API call.
+type Request struct {
+	// Context carries cancellation and trace context. nil means context.Background().
+	Context context.Context
+
+	Method string
+	Path   string     // e.g. "api/v2/issues/1"
+	Query  url.Values // apiKey is added by the client
+	Body   url.Values // sent as application/x-www-form-urlencoded
+	File   *Upload    // if set, Body and File are sent as multipart/form-data
+	Header http.Header
+}
+
+// Upload is a file to upload.
+type Upload struct {
+	Name   string
+	Reader io.Reader
+}
+
+// Response represents a Backlog API response.
+// Non-2xx responses are returned as they are; Repository turns them into errors.
+// The receiver of a Response must close Body.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       io.ReadCloser
 }
 
 type client struct {
@@ -23,6 +61,12 @@ type client struct {
 	httpClient   *http.Client
 }
 
+// NewClient returns the Client that sends requests to https://{subdomain}.backlog.jp.
+// If httpClient is nil, http.DefaultClient is used.
+func NewClient(subdomain, apiKey string, httpClient *http.Client) Client {
+	return newClient(subdomain, apiKey, httpClient)
+}
+
 func newClient(subdomain, apiKey string, httpClient *http.Client) *client {
 	c := client{
 		apiKey: apiKey,
@@ -41,56 +85,95 @@ func newClient(subdomain, apiKey string, httpClient *http.Client) *client {
 	return &c
 }
 
-func (c *client) get(path string, query url.Values) ([]byte, error) {
-	url := c.newURL(path, query)
-	req, err := http.NewRequest("GET", url.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+func (c *client) Do(r *Request) (*Response, error) {
+	url := c.newURL(r.Path, r.Query)
 
-	return c.do(req)
-}
+	var body io.Reader
+	contentType := "application/x-www-form-urlencoded"
+	switch {
+	case r.File != nil:
+		body, contentType = multipartBody(r.Body, r.File)
+	case r.Body != nil:
+		body = strings.NewReader(r.Body.Encode())
+	}
 
-func (c *client) put(path string, params url.Values) ([]byte, error) {
-	url := c.newURL(path, nil)
-	reader := strings.NewReader(params.Encode())
+	ctx := r.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	req, err := http.NewRequest("PATCH", url.String(), reader)
+	req, err := http.NewRequestWithContext(ctx, r.Method, url.String(), body)
 	if err != nil {
+		if c, ok := body.(io.Closer); ok {
+			c.Close()
+		}
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
 
 	return c.do(req)
 }
 
-func (c *client) do(req *http.Request) ([]byte, error) {
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
+// multipartBody streams params and file as multipart/form-data
+// without reading the whole file into memory.
+func multipartBody(params url.Values, file *Upload) (io.Reader, string) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	go func() {
+		err := func() error {
+			for k, vs := range params {
+				for _, v := range vs {
+					if err := w.WriteField(k, v); err != nil {
+						return err
+					}
+				}
+			}
+
+			part, err := w.CreateFormFile("file", file.Name)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(part, file.Reader); err != nil {
+				return err
+			}
+
+			return w.Close()
+		}()
+		pw.CloseWithError(err)
+	}()
+
+	return pr, w.FormDataContentType()
+}
 
-	body, err := ioutil.ReadAll(res.Body)
+func (c *client) do(req *http.Request) (*Response, error) {
+	res, err := c.httpClient.Do(req)
 	if err != nil {
+		// url.Error contains the request URL, which includes apiKey
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = RedactAPIKey(urlErr.URL)
+		}
 		return nil, err
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode != 200 {
-		return nil, errors.New(string(body))
-	}
 
-	return body, nil
+	return &Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       res.Body,
+	}, nil
 }
 
 func (c *client) newURL(path string, query url.Values) *url.URL {
 	u := *c.endpointBase
 	u.Path = path
 	params := url.Values{"apiKey": {c.apiKey}}
-	if query != nil {
-		for k, v := range query {
-			params.Add(k, v[0])
+	for k, vs := range query {
+		// array parameters such as "projectId[]" are sent as repeated keys
+		for _, v := range vs {
+			params.Add(k, v)
 		}
 	}
 	u.RawQuery = params.Encode()
@@ -0,0 +1,207 @@
+package backlog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestRepository returns a Repository that sends requests to a test server running h.
+func newTestRepository(t *testing.T, h http.HandlerFunc) *Repository {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := newClient("test", "secret", srv.Client())
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.endpointBase = u
+
+	return NewRepositoryWithClient(c)
+}
+
+func TestSearchIssuesArrayParams(t *testing.T) {
+	var got url.Values
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/issues" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/api/v2/issues")
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	})
+
+	q := NewSearchIssueQuery()
+	q.SetProjectID(1)
+	q.SetProjectID(2)
+	q.SetStatusID(3)
+	q.SetStatusID(4)
+	q.SetCustomFieldItemID(5, 6)
+	q.SetCustomFieldItemID(5, 7)
+
+	if _, err := repo.SearchIssues(q); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"apiKey":          {"secret"},
+		"projectId[]":     {"1", "2"},
+		"statusId[]":      {"3", "4"},
+		"customField_5[]": {"6", "7"},
+	}
+	for k, v := range want {
+		if !reflect.DeepEqual(got[k], v) {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestNewURLKeepsRepeatedValues(t *testing.T) {
+	c := newClient("example", "secret", nil)
+
+	u := c.newURL("api/v2/issues", url.Values{"projectId[]": {"1", "2"}})
+
+	if u.Host != "example.backlog.jp" {
+		t.Errorf("host = %q, want %q", u.Host, "example.backlog.jp")
+	}
+	q := u.Query()
+	if got, want := q["projectId[]"], []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("projectId[] = %q, want %q", got, want)
+	}
+	if got := q.Get("apiKey"); got != "secret" {
+		t.Errorf("apiKey = %q, want %q", got, "secret")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request %s was sent with a canceled context", r.URL.Path)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.WithContext(ctx).Myself(); err == nil {
+		t.Error("Myself succeeded with a canceled context")
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Client) Client {
+			return ClientFunc(func(req *Request) (*Response, error) {
+				calls = append(calls, name+" before")
+				res, err := next.Do(req)
+				calls = append(calls, name+" after")
+				return res, err
+			})
+		}
+	}
+	c := Chain(ClientFunc(func(req *Request) (*Response, error) {
+		calls = append(calls, "client")
+		return &Response{StatusCode: http.StatusOK}, nil
+	}), record("first"), record("second"))
+
+	if _, err := c.Do(&Request{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"first before", "second before", "client", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestRedactAPIKey(t *testing.T) {
+	tests := map[string]string{
+		"https://x.backlog.jp/api/v2/issues?apiKey=secret":              "https://x.backlog.jp/api/v2/issues?apiKey=REDACTED",
+		"https://x.backlog.jp/api/v2/issues?apiKey=secret&count=1":      "https://x.backlog.jp/api/v2/issues?apiKey=REDACTED&count=1",
+		"https://x.backlog.jp/api/v2/issues?count=1&apiKey=secret":      "https://x.backlog.jp/api/v2/issues?count=1&apiKey=REDACTED",
+		`Get "https://x.backlog.jp/?apiKey=secret": connection refused`: `Get "https://x.backlog.jp/?apiKey=REDACTED": connection refused`,
+		"no key": "no key",
+	}
+	for in, want := range tests {
+		if got := RedactAPIKey(in); got != want {
+			t.Errorf("RedactAPIKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClientRedactsAPIKeyFromErrors(t *testing.T) {
+	c := newClient("test", "secret", nil)
+	// nothing listens on port 0, so the request fails with a url.Error
+	c.endpointBase = &url.URL{Scheme: "http", Host: "127.0.0.1:0"}
+
+	var buf bytes.Buffer
+	repo := NewRepositoryWithClient(c)
+	repo.Use(LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	_, err := repo.Myself()
+	if err == nil {
+		t.Fatal("Myself succeeded without a server")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error contains the API key: %v", err)
+	}
+	if !strings.Contains(err.Error(), "apiKey=REDACTED") {
+		t.Errorf("error does not contain the redacted URL: %v", err)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("log contains the API key: %s", buf.String())
+	}
+}
+
+func TestLoggingAndRequestIDMiddleware(t *testing.T) {
+	var received string
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(RequestIDHeader)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	var buf bytes.Buffer
+	repo.Use(RequestIDMiddleware(), LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	if _, err := repo.Myself(); err != nil {
+		t.Fatal(err)
+	}
+
+	if received == "" {
+		t.Fatal("request ID is not sent")
+	}
+	log := buf.String()
+	for _, want := range []string{"method=GET", "url=api/v2/users/myself", "status=200", "request_id=" + received} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log %q does not contain %q", log, want)
+		}
+	}
+	if strings.Contains(log, "secret") {
+		t.Errorf("log contains the API key: %s", log)
+	}
+}
+
+func TestRequestIDMiddlewareKeepsGivenID(t *testing.T) {
+	var got string
+	c := Chain(ClientFunc(func(req *Request) (*Response, error) {
+		got = req.Header.Get(RequestIDHeader)
+		return &Response{StatusCode: http.StatusOK}, nil
+	}), RequestIDMiddleware())
+
+	req := &Request{Header: http.Header{RequestIDHeader: {"given"}}}
+	if _, err := c.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	if got != "given" {
+		t.Errorf("request ID = %q, want %q", got, "given")
+	}
+}
@@ -0,0 +1,35 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Comment is a comment on an issue.
+type Comment struct {
+	ID          int       `json:"id,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	CreatedUser *User     `json:"createdUser,omitempty"`
+	Created     time.Time `json:"created,omitempty"`
+	Updated     time.Time `json:"updated,omitempty"`
+	Stars       []*Star   `json:"stars,omitempty"`
+}
+
+// AddComment adds a comment to an issue with uploaded files attached.
+func (repo *Repository) AddComment(issueIDOrKey string, content string, attachmentIDs []int) (*Comment, error) {
+	params := url.Values{"content": {content}}
+	for _, id := range attachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("api/v2/issues/%s/comments", issueIDOrKey)
+
+	var c Comment
+	err := repo.post(url, params, &c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,316 @@
+package backlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// CustomFieldDefinition is the definition of a custom field in a project.
+type CustomFieldDefinition struct {
+	ID                   int                    `json:"id"`
+	FieldType            CustomFieldType        `json:"typeId"`
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description"`
+	Required             bool                   `json:"required"`
+	ApplicableIssueTypes []int                  `json:"applicableIssueTypes"` // issue type IDs. empty means all types
+	AllowAddItem         bool                   `json:"allowAddItem"`
+	AllowInput           bool                   `json:"allowInput"`
+	ListItems            []*CustomFieldListItem `json:"items"` // fieldTypeがlistの時のみ
+
+	//+number
+	Min          *float64 `json:"-"`
+	Max          *float64 `json:"-"`
+	InitialValue *float64 `json:"-"`
+	Unit         string   `json:"unit"`
+	//-number
+
+	//+date
+	MinDate          string `json:"-"` // yyyy-MM-dd
+	MaxDate          string `json:"-"` // yyyy-MM-dd
+	InitialValueType int    `json:"initialValueType"`
+	InitialDate      string `json:"initialDate"`
+	InitialShift     int    `json:"initialShift"`
+	//-date
+}
+
+// min and max are numbers for number fields but dates for date fields.
+func (d *CustomFieldDefinition) UnmarshalJSON(data []byte) error {
+	type definition CustomFieldDefinition
+	raw := struct {
+		*definition
+		Min          *json.RawMessage `json:"min"`
+		Max          *json.RawMessage `json:"max"`
+		InitialValue *float64         `json:"initialValue"`
+	}{definition: (*definition)(d)}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	switch d.FieldType {
+	case CustomFieldTypeNumber:
+		d.InitialValue = raw.InitialValue
+		if raw.Min != nil {
+			err = json.Unmarshal(*raw.Min, &d.Min)
+			if err != nil {
+				return err
+			}
+		}
+		if raw.Max != nil {
+			err = json.Unmarshal(*raw.Max, &d.Max)
+			if err != nil {
+				return err
+			}
+		}
+	case CustomFieldTypeDate:
+		if raw.Min != nil {
+			err = json.Unmarshal(*raw.Min, &d.MinDate)
+			if err != nil {
+				return err
+			}
+		}
+		if raw.Max != nil {
+			err = json.Unmarshal(*raw.Max, &d.MaxDate)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *CustomFieldDefinition) params() url.Values {
+	params := url.Values{
+		"name":        {d.Name},
+		"description": {d.Description},
+		"required":    {strconv.FormatBool(d.Required)},
+	}
+	for _, id := range d.ApplicableIssueTypes {
+		params.Add("applicableIssueTypes[]", strconv.Itoa(id))
+	}
+
+	switch d.FieldType {
+	case CustomFieldTypeNumber:
+		if d.Min != nil {
+			params.Set("min", strconv.FormatFloat(*d.Min, 'f', -1, 64))
+		}
+		if d.Max != nil {
+			params.Set("max", strconv.FormatFloat(*d.Max, 'f', -1, 64))
+		}
+		if d.InitialValue != nil {
+			params.Set("initialValue", strconv.FormatFloat(*d.InitialValue, 'f', -1, 64))
+		}
+		params.Set("unit", d.Unit)
+	case CustomFieldTypeDate:
+		if d.MinDate != "" {
+			params.Set("min", d.MinDate)
+		}
+		if d.MaxDate != "" {
+			params.Set("max", d.MaxDate)
+		}
+		if d.InitialValueType != 0 {
+			params.Set("initialValueType", strconv.Itoa(d.InitialValueType))
+			params.Set("initialDate", d.InitialDate)
+			params.Set("initialShift", strconv.Itoa(d.InitialShift))
+		}
+	case CustomFieldTypeSingleList, CustomFieldTypeMultipleList, CustomFieldTypeCheckbox, CustomFieldTypeRadio:
+		params.Set("allowAddItem", strconv.FormatBool(d.AllowAddItem))
+		params.Set("allowInput", strconv.FormatBool(d.AllowInput))
+	}
+
+	return params
+}
+
+//+custom field definition
+type customFieldDefinitions []*CustomFieldDefinition
+
+func (ps customFieldDefinitions) findFieldType(fieldName string) (CustomFieldType, error) {
+	var property *CustomFieldDefinition
+
+	var seenCount int
+	for _, p := range ps {
+		if seenCount > 1 {
+			return 0, fmt.Errorf("field name '%s' is ambiquous", fieldName)
+		}
+		if p.Name == fieldName {
+			property = p
+			seenCount++
+		}
+	}
+
+	if property == nil {
+		return 0, fmt.Errorf("field name '%s' is not found", fieldName)
+	}
+
+	return property.FieldType, nil
+}
+
+func (ps customFieldDefinitions) findFieldID(fieldName string) (int, error) {
+	var property *CustomFieldDefinition
+
+	var seenCount int
+	for _, p := range ps {
+		if seenCount > 1 {
+			return 0, fmt.Errorf("field name '%s' is ambiquous", fieldName)
+		}
+		if p.Name == fieldName {
+			property = p
+			seenCount++
+		}
+	}
+
+	if property == nil {
+		return 0, fmt.Errorf("field name '%s' is not found", fieldName)
+	}
+
+	return property.ID, nil
+}
+
+func (ps customFieldDefinitions) findItemID(fieldName string, itemName string) (int, error) {
+	var property *CustomFieldDefinition
+	var item *CustomFieldListItem
+
+	//+property
+	var seenCount int
+	for _, p := range ps {
+		if seenCount > 1 {
+			return 0, fmt.Errorf("field name '%s' is ambiquous", fieldName)
+		}
+		if p.Name == fieldName {
+			property = p
+			seenCount++
+		}
+	}
+
+	if property == nil {
+		return 0, fmt.Errorf("field name is invalid")
+	}
+	//+property
+
+	//+list item
+	seenCount = 0
+	for _, _item := range property.ListItems {
+		if seenCount > 1 {
+			return 0, fmt.Errorf("field list item name '%s' is ambiguous", item.Name)
+		}
+		if _item.Name == itemName {
+			item = _item
+			seenCount++
+		}
+	}
+
+	if item == nil {
+		return 0, fmt.Errorf("field list item name '%s' is invalid", itemName)
+	}
+	//-list item
+
+	return item.ID, nil
+}
+
+//-custom field definition
+
+func (repo *Repository) ListCustomFields(projectIDOrKey string) ([]*CustomFieldDefinition, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/customFields", projectIDOrKey)
+
+	var ds []*CustomFieldDefinition
+	err := repo.get(url, nil, &ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// CreateCustomField creates a custom field of definition.FieldType.
+// ListItems are created as the initial items of list fields.
+func (repo *Repository) CreateCustomField(projectIDOrKey string, definition *CustomFieldDefinition) (*CustomFieldDefinition, error) {
+	params := definition.params()
+	params.Set("typeId", strconv.Itoa(int(definition.FieldType)))
+	for _, item := range definition.ListItems {
+		params.Add("items[]", item.Name)
+	}
+	url := fmt.Sprintf("api/v2/projects/%s/customFields", projectIDOrKey)
+
+	var created CustomFieldDefinition
+	err := repo.post(url, params, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateCustomField updates the custom field with the same ID as definition.
+// The type and list items are not changed; use the list item methods for items.
+func (repo *Repository) UpdateCustomField(projectIDOrKey string, definition *CustomFieldDefinition) (*CustomFieldDefinition, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/customFields/%d", projectIDOrKey, definition.ID)
+
+	var updated CustomFieldDefinition
+	err := repo.patch(url, definition.params(), &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (repo *Repository) DeleteCustomField(projectIDOrKey string, id int) (*CustomFieldDefinition, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/customFields/%d", projectIDOrKey, id)
+
+	var deleted CustomFieldDefinition
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+//+list items
+
+// AddCustomFieldListItem adds an item to a list custom field and returns the updated field.
+func (repo *Repository) AddCustomFieldListItem(projectIDOrKey string, fieldID int, name string) (*CustomFieldDefinition, error) {
+	params := url.Values{"name": {name}}
+	url := fmt.Sprintf("api/v2/projects/%s/customFields/%d/items", projectIDOrKey, fieldID)
+
+	var updated CustomFieldDefinition
+	err := repo.post(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// UpdateCustomFieldListItem renames an item of a list custom field and returns the updated field.
+func (repo *Repository) UpdateCustomFieldListItem(projectIDOrKey string, fieldID int, item *CustomFieldListItem) (*CustomFieldDefinition, error) {
+	params := url.Values{"name": {item.Name}}
+	url := fmt.Sprintf("api/v2/projects/%s/customFields/%d/items/%d", projectIDOrKey, fieldID, item.ID)
+
+	var updated CustomFieldDefinition
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteCustomFieldListItem deletes an item of a list custom field and returns the updated field.
+func (repo *Repository) DeleteCustomFieldListItem(projectIDOrKey string, fieldID int, itemID int) (*CustomFieldDefinition, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/customFields/%d/items/%d", projectIDOrKey, fieldID, itemID)
+
+	var updated CustomFieldDefinition
+	err := repo.delete(url, nil, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+//-list items
@@ -0,0 +1,48 @@
+package backlog
+
+import (
+	"fmt"
+	"time"
+)
+
+// GitRepository is a git repository hosted by Backlog.
+type GitRepository struct {
+	ID           int        `json:"id,omitempty"`
+	ProjectID    int        `json:"projectId,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	HookURL      string     `json:"hookUrl,omitempty"`
+	HTTPURL      string     `json:"httpUrl,omitempty"`
+	SSHURL       string     `json:"sshUrl,omitempty"`
+	DisplayOrder int        `json:"displayOrder,omitempty"`
+	PushedAt     *time.Time `json:"pushedAt,omitempty"`
+	CreatedUser  *User      `json:"createdUser,omitempty"`
+	Created      time.Time  `json:"created,omitempty"`
+	UpdatedUser  *User      `json:"updatedUser,omitempty"`
+	Updated      time.Time  `json:"updated,omitempty"`
+}
+
+func (repo *Repository) ListGitRepositories(projectIDOrKey string) ([]*GitRepository, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/git/repositories", projectIDOrKey)
+
+	var rs []*GitRepository
+	err := repo.get(url, nil, &rs)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// FindGitRepository finds a git repository by its ID or name.
+func (repo *Repository) FindGitRepository(projectIDOrKey string, repoIDOrName string) (*GitRepository, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/git/repositories/%s", projectIDOrKey, repoIDOrName)
+
+	var r GitRepository
+	err := repo.get(url, nil, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -37,7 +37,7 @@ type Issue struct {
 	Resolution     Resolution    `json:"resolution"`
 	Priority       Priority      `json:"priority"`
 	Status         string        `json:"status"`
-	Assignee       Assignee      `json:"assignee"`
+	Assignee       *User         `json:"assignee"` // nil if unassigned
 	Categories     []*Category   `json:"category"`
 	Versions       []*Version    `json:"versions"`
 	Milestones     []*Version    `json:"milestone"`
@@ -89,33 +89,33 @@ func (d *Date) String() string {
 
 func (i *Issue) UnmarshalJSON(data []byte) error {
 	var raw struct {
-		ID             int             `json:"id,"`
-		ProjectID      int             `json:"projectId"`
-		IssueKey       string          `json:"issueKey"`
-		KeyID          int             `json:"keyId"`
-		IssueType      IssueType       `json:"issueType"`
-		Summary        string          `json:"summary"`
-		Description    string          `json:"description"` // 詳細
-		Resolution     Resolution      `json:"resolution"`
-		Priority       Priority        `json:"priority"`
-		Status         issueStatusItem `json:"status"` // status
-		Assignee       Assignee        `json:"assignee"`
-		Categories     []*Category     `json:"category"`
-		Versions       []*Version      `json:"versions"`
-		Milestones     []*Version      `json:"milestone"`
-		StartDate      time.Time       `json:"startDate"`
-		DueDate        time.Time       `json:"dueDate"`
-		EstimatedHours int             `json:"estimatedHours"`
-		ActualHours    int             `json:"actualHours"`
-		ParentIssueID  int             `json:"parentIssueId"`
-		CreatedUser    *User           `json:"createdUser"`
-		Created        time.Time       `json:"created"`
-		UpdatedUser    *User           `json:"updatedUser"`
-		Updated        time.Time       `json:"updated"`
-		CustomFields   CustomFields    `json:"customFields"`
-		Attachments    []*Attachment   `json:"attachments"`
-		SharedFiles    []*SharedFile   `json:"sharedFiles"`
-		Stars          []*Star         `json:"stars"`
+		ID             int           `json:"id,"`
+		ProjectID      int           `json:"projectId"`
+		IssueKey       string        `json:"issueKey"`
+		KeyID          int           `json:"keyId"`
+		IssueType      IssueType     `json:"issueType"`
+		Summary        string        `json:"summary"`
+		Description    string        `json:"description"` // 詳細
+		Resolution     Resolution    `json:"resolution"`
+		Priority       Priority      `json:"priority"`
+		Status         Status        `json:"status"` // status
+		Assignee       *User         `json:"assignee"`
+		Categories     []*Category   `json:"category"`
+		Versions       []*Version    `json:"versions"`
+		Milestones     []*Version    `json:"milestone"`
+		StartDate      time.Time     `json:"startDate"`
+		DueDate        time.Time     `json:"dueDate"`
+		EstimatedHours int           `json:"estimatedHours"`
+		ActualHours    int           `json:"actualHours"`
+		ParentIssueID  int           `json:"parentIssueId"`
+		CreatedUser    *User         `json:"createdUser"`
+		Created        time.Time     `json:"created"`
+		UpdatedUser    *User         `json:"updatedUser"`
+		Updated        time.Time     `json:"updated"`
+		CustomFields   CustomFields  `json:"customFields"`
+		Attachments    []*Attachment `json:"attachments"`
+		SharedFiles    []*SharedFile `json:"sharedFiles"`
+		Stars          []*Star       `json:"stars"`
 		Comment        struct {
 			ID      int    `json:"id"`
 			Content string `json:"content"`
@@ -161,133 +161,310 @@ func (i *Issue) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-type IssueType struct {
-	ID           int    `json:"id,omitempty"`
-	ProjectID    int    `json:"projectId,omitempty"`
-	Name         string `json:"name,omitempty"`
-	Color        string `json:"color,omitempty"`
-	DisplayOrder int    `json:"displayOrder,omitempty"`
+type Issues []*Issue
+
+//+sort
+func (x Issues) Len() int {
+	return len(x)
 }
 
-type Resolution struct {
-	ID   *int    `json:"id,omitempty"`
-	Name *string `json:"name,omitempty"`
+func (x Issues) Less(i, j int) bool {
+	return x[i].ID < x[j].ID
 }
 
-// Priority represents
-type Priority struct {
-	ID   *int    `json:"id,omitempty"`
-	Name *string `json:"name,omitempty"`
+func (x Issues) Swap(i, j int) {
+	x[i], x[j] = x[j], x[i]
 }
 
-// Assignee represents
-type Assignee struct {
-	ID          int    `json:"id,omitempty"`
-	UserID      string `json:"userId,omitempty"`
-	Name        string `json:"name,omitempty"`
-	RoleType    int    `json:"roleType,omitempty"`
-	Lang        string `json:"lang,omitempty"`
-	MailAddress string `json:"mailAddress,omitempty"`
+//-sort
+
+// issueMetadata holds the project settings that Issue.params resolves names with.
+// Each list is fetched when it is first needed, so that an update only spends
+// requests on the fields it resolves by name.
+type issueMetadata struct {
+	repo      *Repository
+	projectID string
+
+	customFields customFieldDefinitions
+	statuses     statuses
+	issueTypes   issueTypes
+	priorities   priorities
+	resolutions  resolutions
+	categories   categories
+	versions     versions
+	users        users
 }
 
-type User struct {
-	ID           int    `json:"id,omitempty"`
-	UserID       string `json:"userId,omitempty"`
-	Name         string `json:"name,omitempty"`
-	RoleType     int    `json:"roleType,omitempty"`
-	Lang         string `json:"lang,omitempty"`
-	MailAddress  string `json:"mailAddress,omitempty"`
-	NulabAccount string `json:"nulabAccount,omitempty"`
+func (repo *Repository) newIssueMetadata(projectID int) *issueMetadata {
+	return &issueMetadata{repo: repo, projectID: strconv.Itoa(projectID)}
 }
 
-type Category struct {
-	ID           int    `json:"id,omitempty"`
-	Name         string `json:"name,omitempty"`
-	DisplayOrder int    `json:"displayOrder,omitempty"`
+func (m *issueMetadata) getCustomFields() (customFieldDefinitions, error) {
+	if m.customFields == nil {
+		fs, err := m.repo.ListCustomFields(m.projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "list custom fields failed")
+		}
+		m.customFields = fs
+	}
+	return m.customFields, nil
 }
 
-type Version struct {
-	ID             int       `json:"id,omitempty"`
-	ProjectID      int       `json:"projectId,omitempty"`
-	Name           string    `json:"name,omitempty"`
-	Description    string    `json:"description,omitempty"`
-	StartDate      time.Time `json:"startDate,omitempty"`
-	ReleaseDueDate time.Time `json:"releaseDueDate,omitempty"`
-	Archived       bool      `json:"archived,omitempty"`
-	DisplayOrder   int       `json:"displayOrder,omitempty"`
+func (m *issueMetadata) getStatuses() (statuses, error) {
+	if m.statuses == nil {
+		ss, err := m.repo.ListStatuses(m.projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "list statuses failed")
+		}
+		m.statuses = ss
+	}
+	return m.statuses, nil
 }
 
-type Attachment struct {
-	ID          int       `json:"id,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	Size        int64     `json:"size,omitempty"`
-	CreatedUser User      `json:"createdUser,omitempty"`
-	Created     time.Time `json:"created,omitempty"`
+func (m *issueMetadata) getIssueTypes() (issueTypes, error) {
+	if m.issueTypes == nil {
+		ts, err := m.repo.ListIssueTypes(m.projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "list issue types failed")
+		}
+		m.issueTypes = ts
+	}
+	return m.issueTypes, nil
 }
 
-type SharedFile struct {
-	ID          int       `json:"id,omitempty"`
-	Type        string    `json:"type,omitempty"`
-	Directory   string    `json:"dir,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	Size        int64     `json:"size,omitempty"`
-	CreatedUser *User     `json:"createdUser,omitempty"`
-	Created     time.Time `json:"created,omitempty"`
-	UpdatedUser *User     `json:"updatedUser,omitempty"`
-	Updated     time.Time `json:"updated,omitempty"`
+func (m *issueMetadata) getPriorities() (priorities, error) {
+	if m.priorities == nil {
+		ps, err := m.repo.ListPriorities()
+		if err != nil {
+			return nil, errors.Wrap(err, "list priorities failed")
+		}
+		m.priorities = ps
+	}
+	return m.priorities, nil
 }
 
-type Star struct {
-	ID        int       `json:"id,omitempty"`
-	Comment   string    `json:"comment,omitempty"`
-	URL       string    `json:"url,omitempty"`
-	Title     string    `json:"name,omitempty"`
-	Created   time.Time `json:"created,omitempty"`
-	Presenter *User     `json:"presenter,omitempty"`
+func (m *issueMetadata) getResolutions() (resolutions, error) {
+	if m.resolutions == nil {
+		rs, err := m.repo.ListResolutions()
+		if err != nil {
+			return nil, errors.Wrap(err, "list resolutions failed")
+		}
+		m.resolutions = rs
+	}
+	return m.resolutions, nil
 }
 
-type Issues []*Issue
+func (m *issueMetadata) getCategories() (categories, error) {
+	if m.categories == nil {
+		cs, err := m.repo.ListCategories(m.projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "list categories failed")
+		}
+		m.categories = cs
+	}
+	return m.categories, nil
+}
 
-// IssueStatusItem
-type issueStatusItem struct {
-	ID           int    `json:"id,omitempty"`
-	ProjectID    int    `json:"projectId,omitempty"`
-	Name         string `json:"name,omitempty"`
-	Color        string `json:"color,omitempty"`
-	DisplayOrder int    `json:"displayOrder,omitempty"`
+// getVersions includes archived versions, which issues can still refer to.
+func (m *issueMetadata) getVersions() (versions, error) {
+	if m.versions == nil {
+		vs, err := m.repo.ListVersions(m.projectID, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "list versions failed")
+		}
+		m.versions = vs
+	}
+	return m.versions, nil
 }
 
-//+sort
-func (x Issues) Len() int {
-	return len(x)
+func (m *issueMetadata) getUsers() (users, error) {
+	if m.users == nil {
+		us, err := m.repo.ListProjectUsers(m.projectID, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "list project users failed")
+		}
+		m.users = us
+	}
+	return m.users, nil
 }
 
-func (x Issues) Less(i, j int) bool {
-	return x[i].ID < x[j].ID
+func (m *issueMetadata) findIssueTypeID(name string) (int, error) {
+	ts, err := m.getIssueTypes()
+	if err != nil {
+		return 0, err
+	}
+	return ts.findID(name)
 }
 
-func (x Issues) Swap(i, j int) {
-	x[i], x[j] = x[j], x[i]
+func (m *issueMetadata) findPriorityID(name string) (int, error) {
+	ps, err := m.getPriorities()
+	if err != nil {
+		return 0, err
+	}
+	return ps.findID(name)
 }
 
-//-sort
+func (m *issueMetadata) findResolutionID(name string) (int, error) {
+	rs, err := m.getResolutions()
+	if err != nil {
+		return 0, err
+	}
+	return rs.findID(name)
+}
+
+// resolveID returns id, or the ID findID finds for name if name is set.
+// It returns an error if both are set and disagree.
+func resolveID(field string, id *int, name string, findID func(name string) (int, error)) (*int, error) {
+	if name == "" {
+		return id, nil
+	}
 
-func (i *Issue) params(property customFieldProperties, issueStatusItems []*issueStatusItem) (url.Values, error) {
+	found, err := findID(name)
+	if err != nil {
+		return nil, err
+	}
+	if id != nil && *id != found {
+		return nil, fmt.Errorf("%s ID %d does not match the name '%s' (ID %d); clear the one you did not change", field, *id, name, found)
+	}
 
-	//+issueStatus
-	var issueStatus string
-	for _, item := range issueStatusItems {
-		if item.Name == i.Status {
-			issueStatus = strconv.Itoa(item.ID)
-		}
+	return &found, nil
+}
+
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
 	}
-	//-issueStatus
+	return *p
+}
 
+// resolveVersion returns the ID of v, looking it up by name only if v has no ID.
+func (m *issueMetadata) resolveVersion(v *Version) (int, error) {
+	if v.ID != 0 {
+		return v.ID, nil
+	}
+	vs, err := m.getVersions()
+	if err != nil {
+		return 0, err
+	}
+	return vs.findID(v.Name)
+}
+
+func (i *Issue) params(m *issueMetadata) (url.Values, error) {
 	params := url.Values{
 		"summary": {i.Summary},
 		// "parentIssueId": {strconv.Itoa(i.ParentIssueID)},
 		"description": {i.Description},
-		"statusId":    {issueStatus},
+	}
+
+	//+issueStatus
+	if i.Status != "" {
+		ss, err := m.getStatuses()
+		if err != nil {
+			return nil, err
+		}
+		statusID, err := ss.findID(i.Status)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("statusId", strconv.Itoa(statusID))
+	}
+	//-issueStatus
+
+	//+issueType, priority, resolution
+	// a name is resolved to its ID. If both are set, they must agree, so that
+	// changing only one of them on a loaded issue fails instead of being ignored.
+	// resolution IDs start at 0, so nil means unset for priorities and resolutions
+	var issueTypeID *int
+	if i.IssueType.ID != 0 {
+		issueTypeID = &i.IssueType.ID
+	}
+	if id, err := resolveID("issue type", issueTypeID, i.IssueType.Name, m.findIssueTypeID); err != nil {
+		return nil, err
+	} else if id != nil {
+		params.Set("issueTypeId", strconv.Itoa(*id))
+	}
+
+	if id, err := resolveID("priority", i.Priority.ID, stringValue(i.Priority.Name), m.findPriorityID); err != nil {
+		return nil, err
+	} else if id != nil {
+		params.Set("priorityId", strconv.Itoa(*id))
+	}
+
+	if id, err := resolveID("resolution", i.Resolution.ID, stringValue(i.Resolution.Name), m.findResolutionID); err != nil {
+		return nil, err
+	} else if id != nil {
+		params.Set("resolutionId", strconv.Itoa(*id))
+	}
+	//-issueType, priority, resolution
+
+	//+assignee
+	// the assignee is looked up by mail address, user ID or name only if it has no ID.
+	// nil Assignee unassigns the issue
+	if i.Assignee == nil {
+		params.Set("assigneeId", "")
+	} else {
+		id := i.Assignee.ID
+		if id == 0 {
+			key := firstNonEmpty(i.Assignee.MailAddress, i.Assignee.UserID, i.Assignee.Name)
+			if key == "" {
+				return nil, errors.New("assignee has neither ID, mail address, user ID nor name")
+			}
+			us, err := m.getUsers()
+			if err != nil {
+				return nil, err
+			}
+			u, err := us.find(key)
+			if err != nil {
+				return nil, err
+			}
+			id = u.ID
+		}
+		params.Set("assigneeId", strconv.Itoa(id))
+	}
+	//-assignee
+
+	//+categories
+	// names are resolved only for categories without an ID
+	for _, c := range i.Categories {
+		id := c.ID
+		if id == 0 {
+			cs, err := m.getCategories()
+			if err != nil {
+				return nil, err
+			}
+			id, err = cs.findID(c.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		params.Add("categoryId[]", strconv.Itoa(id))
+	}
+	//-categories
+
+	//+versions, milestones
+	for _, v := range i.Versions {
+		id, err := m.resolveVersion(v)
+		if err != nil {
+			return nil, err
+		}
+		params.Add("versionId[]", strconv.Itoa(id))
+	}
+	for _, v := range i.Milestones {
+		id, err := m.resolveVersion(v)
+		if err != nil {
+			return nil, err
+		}
+		params.Add("milestoneId[]", strconv.Itoa(id))
+	}
+	//-versions, milestones
+
+	var property customFieldDefinitions
+	if len(i.CustomFields) > 0 {
+		var err error
+		property, err = m.getCustomFields()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	for fieldName, customField := range i.CustomFields {
@@ -367,7 +544,7 @@ func (f SentenceCustomField) String() string {
 type NumberCustomField int
 
 func (f NumberCustomField) String() string {
-	return string(f)
+	return strconv.Itoa(int(f))
 }
 
 type DateCustomField time.Time
@@ -446,7 +623,7 @@ func (fs *CustomFields) UnmarshalJSON(data []byte) error {
 			}
 			customField = _f
 		case CustomFieldTypeSingleList:
-			var item customFieldListItem
+			var item CustomFieldListItem
 			err = json.Unmarshal(*r.Value, &item)
 			if err != nil {
 				log.Println(string(*r.Value))
@@ -454,14 +631,14 @@ func (fs *CustomFields) UnmarshalJSON(data []byte) error {
 			}
 			customField = SingleListCustomField(item.Name)
 		case CustomFieldTypeRadio:
-			var item customFieldListItem
+			var item CustomFieldListItem
 			err = json.Unmarshal(*r.Value, &item)
 			if err != nil {
 				return err
 			}
 			customField = RadioCustomField(item.Name)
 		case CustomFieldTypeCheckbox:
-			var items []*customFieldListItem
+			var items []*CustomFieldListItem
 
 			err = json.Unmarshal(*r.Value, &items)
 			if err != nil {
@@ -474,7 +651,7 @@ func (fs *CustomFields) UnmarshalJSON(data []byte) error {
 			}
 			customField = CheckboxCustomField(vs)
 		case CustomFieldTypeMultipleList:
-			var items []*customFieldListItem
+			var items []*CustomFieldListItem
 			err = json.Unmarshal(*r.Value, &items)
 			if err != nil {
 				return err
@@ -499,7 +676,7 @@ func (fs *CustomFields) UnmarshalJSON(data []byte) error {
 // 	type Raw struct {
 // 		FieldType CustomFieldType  `json:"fieldTypeId"`
 // 		Name      string           `json:"name"`
-// 		Value     *json.RawMessage `json:"value"` // string or customFieldListItem
+// 		Value     *json.RawMessage `json:"value"` // string or CustomFieldListItem
 // 	}
 
 // 	var raw Raw
@@ -523,14 +700,14 @@ func (fs *CustomFields) UnmarshalJSON(data []byte) error {
 // 		}
 // 		f.Value = s
 // 	case CustomFieldTypeSingleList, CustomFieldTypeRadio:
-// 		var i customFieldListItem
+// 		var i CustomFieldListItem
 // 		err := json.Unmarshal(*raw.Value, &i)
 // 		if err != nil {
 // 			return err
 // 		}
 // 		f.Value = i.Name
 // 	case CustomFieldTypeMultipleList, CustomFieldTypeCheckbox:
-// 		var is []*customFieldListItem
+// 		var is []*CustomFieldListItem
 // 		err := json.Unmarshal(*raw.Value, &is)
 // 		if err != nil {
 // 			return err
@@ -546,105 +723,11 @@ func (fs *CustomFields) UnmarshalJSON(data []byte) error {
 // 	return nil
 // }
 
-type customFieldListItem struct {
+// CustomFieldListItem is an item of list, checkbox and radio custom fields.
+type CustomFieldListItem struct {
 	ID           int    `json:"id"`
 	Name         string `json:"name"`
 	DisplayOrder int    `json:"displayOrder"`
 }
 
 //-custom field
-
-//+custom field property
-type customFieldProperties []*customFieldProperty
-
-func (ps customFieldProperties) findFieldType(fieldName string) (CustomFieldType, error) {
-	var property *customFieldProperty
-
-	var seenCount int
-	for _, p := range ps {
-		if seenCount > 1 {
-			return 0, fmt.Errorf("field name '%s' is ambiquous", fieldName)
-		}
-		if p.Name == fieldName {
-			property = p
-			seenCount++
-		}
-	}
-
-	if property == nil {
-		return 0, fmt.Errorf("field name '%s' is not found", fieldName)
-	}
-
-	return property.FieldType, nil
-}
-
-func (ps customFieldProperties) findFieldID(fieldName string) (int, error) {
-	var property *customFieldProperty
-
-	var seenCount int
-	for _, p := range ps {
-		if seenCount > 1 {
-			return 0, fmt.Errorf("field name '%s' is ambiquous", fieldName)
-		}
-		if p.Name == fieldName {
-			property = p
-			seenCount++
-		}
-	}
-
-	return property.ID, nil
-}
-
-func (ps customFieldProperties) findItemID(fieldName string, itemName string) (int, error) {
-	var property *customFieldProperty
-	var item *customFieldListItem
-
-	//+property
-	var seenCount int
-	for _, p := range ps {
-		if seenCount > 1 {
-			return 0, fmt.Errorf("field name '%s' is ambiquous", fieldName)
-		}
-		if p.Name == fieldName {
-			property = p
-			seenCount++
-		}
-	}
-
-	if property == nil {
-		return 0, fmt.Errorf("field name is invalid")
-	}
-	//+property
-
-	//+list item
-	seenCount = 0
-	for _, _item := range property.ListItems {
-		if seenCount > 1 {
-			return 0, fmt.Errorf("field list item name '%s' is ambiguous", item.Name)
-		}
-		if _item.Name == itemName {
-			item = _item
-			seenCount++
-		}
-	}
-
-	if item == nil {
-		return 0, fmt.Errorf("field list item name '%s' is invalid", itemName)
-	}
-	//-list item
-
-	return item.ID, nil
-}
-
-type customFieldProperty struct {
-	ID                   int                    `json:"id"`
-	FieldType            CustomFieldType        `json:"typeID"`
-	Name                 string                 `json:"name"`
-	Description          string                 `json:"description"`
-	Required             bool                   `json:"required"`
-	ApplicableIssueTypes []interface{}          `json:"applicableIssueTypes"`
-	AllowAddItem         bool                   `json:"allowAddItem"`
-	ListItems            []*customFieldListItem `json:"items"` // fieldTypeがlistの時のみ
-}
-
-//-custom field property
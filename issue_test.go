@@ -0,0 +1,245 @@
+package backlog
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+const testIssueJSON = `{
+	"id": 1,
+	"projectId": 10,
+	"issueKey": "PRJ-1",
+	"summary": "summary",
+	"issueType": {"id": 2, "name": "Bug"},
+	"priority": {"id": 3, "name": "Normal"},
+	"resolution": null,
+	"status": {"id": 1, "name": "Open"},
+	"assignee": null,
+	"category": [{"id": 4, "name": "UI"}],
+	"versions": [{"id": 6, "name": "1.0"}],
+	"milestone": [],
+	"customFields": []
+}`
+
+// issueServer serves testIssueJSON and project metadata, and records the requests except the initial GET.
+type issueServer struct {
+	t        *testing.T
+	requests []string
+	form     url.Values
+}
+
+func (s *issueServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/issues/1":
+		w.Write([]byte(testIssueJSON))
+		return
+	case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/issues/1":
+		if err := r.ParseForm(); err != nil {
+			s.t.Error(err)
+		}
+		s.form = r.PostForm
+		w.Write([]byte(testIssueJSON))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/projects/10/categories":
+		w.Write([]byte(`[{"id": 4, "name": "UI"}, {"id": 5, "name": "API"}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/projects/10/versions":
+		w.Write([]byte(`[{"id": 6, "name": "1.0"}, {"id": 7, "name": "1.1", "archived": true}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/projects/10/users":
+		w.Write([]byte(`[{"id": 9, "userId": "bob", "name": "Bob", "mailAddress": "bob@example.com"}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/projects/10/issueTypes":
+		w.Write([]byte(`[{"id": 2, "name": "Bug"}, {"id": 5, "name": "Task"}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/priorities":
+		w.Write([]byte(`[{"id": 2, "name": "High"}, {"id": 3, "name": "Normal"}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/resolutions":
+		w.Write([]byte(`[{"id": 0, "name": "Fixed"}, {"id": 1, "name": "Won't Fix"}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/projects/10/statuses":
+		w.Write([]byte(`[{"id": 1, "name": "Open"}, {"id": 2, "name": "In Progress"}]`))
+	default:
+		w.Write([]byte(`[]`))
+	}
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+}
+
+func TestUpdateIssueFetchesOnlyNeededMetadata(t *testing.T) {
+	s := &issueServer{t: t}
+	repo := newTestRepository(t, s.ServeHTTP)
+
+	issue, err := repo.FindIssue(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /api/v2/projects/10/statuses",
+		"GET /api/v2/projects/10/issueTypes",
+		"GET /api/v2/priorities",
+		"PATCH /api/v2/issues/1",
+	}
+	if !reflect.DeepEqual(s.requests, want) {
+		t.Errorf("requests = %q, want %q", s.requests, want)
+	}
+}
+
+func TestUpdateIssueIDsAndNames(t *testing.T) {
+	id := func(id int) *int { return &id }
+	name := func(name string) *string { return &name }
+
+	tests := []struct {
+		name    string
+		change  func(issue *Issue)
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "IDs",
+			change: func(issue *Issue) {
+				issue.IssueType = IssueType{ID: 5}
+				issue.Priority = Priority{ID: id(2)}
+				issue.Resolution = Resolution{ID: id(1)}
+			},
+			want: map[string]string{"issueTypeId": "5", "priorityId": "2", "resolutionId": "1"},
+		},
+		{
+			name: "names",
+			change: func(issue *Issue) {
+				issue.IssueType = IssueType{Name: "Task"}
+				issue.Priority = Priority{Name: name("High")}
+				issue.Resolution = Resolution{Name: name("Fixed")}
+			},
+			want: map[string]string{"issueTypeId": "5", "priorityId": "2", "resolutionId": "0"},
+		},
+		{
+			name: "only the name of a loaded issue",
+			change: func(issue *Issue) {
+				issue.IssueType.Name = "Task"
+			},
+			wantErr: true,
+		},
+		{
+			name: "only the ID of a loaded issue",
+			change: func(issue *Issue) {
+				*issue.Priority.ID = 2
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &issueServer{t: t}
+			repo := newTestRepository(t, s.ServeHTTP)
+
+			issue, err := repo.FindIssue(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.change(issue)
+
+			err = repo.UpdateIssue(issue)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("UpdateIssue succeeded with disagreeing ID and name")
+				}
+				if s.form != nil {
+					t.Error("the issue was updated")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for k, v := range tt.want {
+				if got := s.form.Get(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateIssueResolvesCategoryNames(t *testing.T) {
+	s := &issueServer{t: t}
+	repo := newTestRepository(t, s.ServeHTTP)
+
+	issue, err := repo.FindIssue(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue.Categories = append(issue.Categories, &Category{Name: "API"})
+
+	if err := repo.UpdateIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := s.form["categoryId[]"], []string{"4", "5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("categoryId[] = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateIssueResolvesVersionNames(t *testing.T) {
+	s := &issueServer{t: t}
+	repo := newTestRepository(t, s.ServeHTTP)
+
+	issue, err := repo.FindIssue(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue.Versions = append(issue.Versions, &Version{Name: "1.1"})
+	issue.Milestones = []*Version{{Name: "1.0"}}
+
+	if err := repo.UpdateIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := s.form["versionId[]"], []string{"6", "7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("versionId[] = %q, want %q", got, want)
+	}
+	if got, want := s.form["milestoneId[]"], []string{"6"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("milestoneId[] = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateIssueAssignee(t *testing.T) {
+	tests := []struct {
+		name     string
+		assignee *User
+		want     string
+		fetch    bool
+	}{
+		{name: "ID", assignee: &User{ID: 8, Name: "Former Member"}, want: "8"},
+		{name: "mail address", assignee: &User{MailAddress: "bob@example.com"}, want: "9", fetch: true},
+		{name: "nil", assignee: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &issueServer{t: t}
+			repo := newTestRepository(t, s.ServeHTTP)
+
+			issue, err := repo.FindIssue(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			issue.Assignee = tt.assignee
+
+			if err := repo.UpdateIssue(issue); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := s.form["assigneeId"]; !reflect.DeepEqual(got, []string{tt.want}) {
+				t.Errorf("assigneeId = %q, want %q", got, tt.want)
+			}
+			fetched := false
+			for _, r := range s.requests {
+				fetched = fetched || r == "GET /api/v2/projects/10/users"
+			}
+			if fetched != tt.fetch {
+				t.Errorf("project users fetched = %v, want %v", fetched, tt.fetch)
+			}
+		})
+	}
+}
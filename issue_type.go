@@ -0,0 +1,106 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Colors available for issue types
+const (
+	IssueTypeColorRed     = "#e30000"
+	IssueTypeColorDarkRed = "#990000"
+	IssueTypeColorPurple  = "#934981"
+	IssueTypeColorViolet  = "#814fbc"
+	IssueTypeColorBlue    = "#2779ca"
+	IssueTypeColorTeal    = "#007e9a"
+	IssueTypeColorGreen   = "#7ea800"
+	IssueTypeColorOrange  = "#ff9200"
+	IssueTypeColorPink    = "#ff3265"
+	IssueTypeColorGray    = "#666665"
+)
+
+type IssueType struct {
+	ID                  int    `json:"id,omitempty"`
+	ProjectID           int    `json:"projectId,omitempty"`
+	Name                string `json:"name,omitempty"`
+	Color               string `json:"color,omitempty"`
+	DisplayOrder        int    `json:"displayOrder,omitempty"`
+	TemplateSummary     string `json:"templateSummary,omitempty"`
+	TemplateDescription string `json:"templateDescription,omitempty"`
+}
+
+func (t *IssueType) params() url.Values {
+	return url.Values{
+		"name":                {t.Name},
+		"color":               {t.Color},
+		"templateSummary":     {t.TemplateSummary},
+		"templateDescription": {t.TemplateDescription},
+	}
+}
+
+type issueTypes []*IssueType
+
+// findID returns the ID of the issue type named name.
+func (ts issueTypes) findID(name string) (int, error) {
+	for _, t := range ts {
+		if t.Name == name {
+			return t.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("issue type '%s' is not found", name)
+}
+
+func (repo *Repository) ListIssueTypes(projectIDOrKey string) ([]*IssueType, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/issueTypes", projectIDOrKey)
+
+	var types []*IssueType
+	err := repo.get(url, nil, &types)
+	if err != nil {
+		return nil, err
+	}
+
+	return types, nil
+}
+
+// CreateIssueType creates an issue type in the project.
+// Color must be one of the IssueTypeColor constants.
+func (repo *Repository) CreateIssueType(projectIDOrKey string, issueType *IssueType) (*IssueType, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/issueTypes", projectIDOrKey)
+
+	var created IssueType
+	err := repo.post(url, issueType.params(), &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateIssueType updates the issue type with the same ID as issueType.
+func (repo *Repository) UpdateIssueType(projectIDOrKey string, issueType *IssueType) (*IssueType, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/issueTypes/%d", projectIDOrKey, issueType.ID)
+
+	var updated IssueType
+	err := repo.patch(url, issueType.params(), &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteIssueType deletes an issue type.
+// Issues of the deleted type are changed to the type of substituteID.
+func (repo *Repository) DeleteIssueType(projectIDOrKey string, id int, substituteID int) (*IssueType, error) {
+	params := url.Values{"substituteIssueTypeId": {strconv.Itoa(substituteID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/issueTypes/%d", projectIDOrKey, id)
+
+	var deleted IssueType
+	err := repo.delete(url, params, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
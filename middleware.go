@@ -0,0 +1,109 @@
+package backlog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestIDHeader is the header RequestIDMiddleware sets on each request.
+const RequestIDHeader = "X-Request-Id"
+
+// Middleware wraps a Client to run code around every request.
+type Middleware func(next Client) Client
+
+// Chain wraps c with ms. The first middleware is the outermost one.
+func Chain(c Client, ms ...Middleware) Client {
+	for i := len(ms) - 1; i >= 0; i-- {
+		c = ms[i](c)
+	}
+	return c
+}
+
+// Use wraps the client of repo with ms.
+func (repo *Repository) Use(ms ...Middleware) {
+	repo.client = Chain(repo.client, ms...)
+}
+
+// LoggingMiddleware logs every request with its method, URL, status and duration.
+// apiKey is redacted from the logged URL.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next Client) Client {
+		return ClientFunc(func(req *Request) (*Response, error) {
+			start := time.Now()
+			res, err := next.Do(req)
+
+			attrs := []any{
+				slog.String("method", req.Method),
+				slog.String("url", requestURL(req)),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if id := req.Header.Get(RequestIDHeader); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			if err != nil {
+				logger.Error("backlog request failed", append(attrs, slog.String("error", RedactAPIKey(err.Error())))...)
+				return res, err
+			}
+			attrs = append(attrs, slog.Int("status", res.StatusCode))
+
+			if res.StatusCode < 200 || res.StatusCode >= 300 {
+				logger.Warn("backlog request", attrs...)
+			} else {
+				logger.Info("backlog request", attrs...)
+			}
+			return res, err
+		})
+	}
+}
+
+// TimingMiddleware calls observe with the duration of every request,
+// measured until the response headers are received.
+// res is nil when the request failed before a response was received.
+func TimingMiddleware(observe func(req *Request, res *Response, d time.Duration)) Middleware {
+	return func(next Client) Client {
+		return ClientFunc(func(req *Request) (*Response, error) {
+			start := time.Now()
+			res, err := next.Do(req)
+			observe(req, res, time.Since(start))
+			return res, err
+		})
+	}
+}
+
+// RequestIDMiddleware sets a random ID to RequestIDHeader unless the request already has one.
+func RequestIDMiddleware() Middleware {
+	return func(next Client) Client {
+		return ClientFunc(func(req *Request) (*Response, error) {
+			if req.Header.Get(RequestIDHeader) == "" {
+				if req.Header == nil {
+					req.Header = make(http.Header)
+				}
+				req.Header.Set(RequestIDHeader, newRequestID())
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// RedactAPIKey replaces the value of apiKey in every URL found in s.
+func RedactAPIKey(s string) string {
+	return apiKeyPattern.ReplaceAllString(s, "${1}REDACTED")
+}
+
+func requestURL(req *Request) string {
+	u := url.URL{Path: req.Path, RawQuery: req.Query.Encode()}
+	return RedactAPIKey(u.String())
+}
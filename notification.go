@@ -0,0 +1,125 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Reasons of notifications
+const (
+	NotificationReasonAssigned = iota + 1
+	NotificationReasonCommented
+	NotificationReasonIssueCreated
+	NotificationReasonIssueUpdated
+	NotificationReasonFileAdded
+	NotificationReasonProjectUserAdded
+	_
+	_
+	NotificationReasonOther
+	NotificationReasonPullRequestAssigned
+	NotificationReasonPullRequestCommented
+	NotificationReasonPullRequestAdded
+	NotificationReasonPullRequestUpdated
+)
+
+// Notification is a notification to the user of the API key.
+// Issue, Comment, PullRequest and PullRequestComment are nil unless they relate to Reason.
+type Notification struct {
+	ID                  int          `json:"id,omitempty"`
+	AlreadyRead         bool         `json:"alreadyRead,omitempty"`
+	Reason              int          `json:"reason,omitempty"`
+	ResourceAlreadyRead bool         `json:"resourceAlreadyRead,omitempty"`
+	Project             *Project     `json:"project,omitempty"`
+	Issue               *Issue       `json:"issue,omitempty"`
+	Comment             *Comment     `json:"comment,omitempty"`
+	PullRequest         *PullRequest `json:"pullRequest,omitempty"`
+	PullRequestComment  *Comment     `json:"pullRequestComment,omitempty"`
+	Sender              *User        `json:"sender,omitempty"`
+	Created             time.Time    `json:"created,omitempty"`
+}
+
+//+NotificationQuery
+
+type NotificationQuery url.Values
+
+func NewNotificationQuery() NotificationQuery {
+	q := NotificationQuery{}
+	return q
+}
+
+func (q NotificationQuery) SetMinID(id int) {
+	url.Values(q).Set("minId", strconv.Itoa(id))
+	return
+}
+
+func (q NotificationQuery) SetMaxID(id int) {
+	url.Values(q).Set("maxId", strconv.Itoa(id))
+	return
+}
+
+// SetCount sets the number of notifications to list, 1 to 100.
+func (q NotificationQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+// SetOrder sets "asc" or "desc".
+func (q NotificationQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+//-NotificationQuery
+
+func (repo *Repository) ListNotifications(q NotificationQuery) ([]*Notification, error) {
+	var ns []*Notification
+	err := repo.get("api/v2/notifications", url.Values(q), &ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
+
+// CountNotifications counts the notifications filtered by alreadyRead and resourceAlreadyRead.
+// A nil filter matches both read and unread ones.
+func (repo *Repository) CountNotifications(alreadyRead, resourceAlreadyRead *bool) (int, error) {
+	query := url.Values{}
+	if alreadyRead != nil {
+		query.Set("alreadyRead", strconv.FormatBool(*alreadyRead))
+	}
+	if resourceAlreadyRead != nil {
+		query.Set("resourceAlreadyRead", strconv.FormatBool(*resourceAlreadyRead))
+	}
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.get("api/v2/notifications/count", query, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+// ResetUnreadNotificationCount resets the unread count shown in Backlog
+// and returns the count before the reset.
+func (repo *Repository) ResetUnreadNotificationCount() (int, error) {
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.post("api/v2/notifications/markAsRead", nil, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+func (repo *Repository) MarkNotificationAsRead(id int) error {
+	url := fmt.Sprintf("api/v2/notifications/%d/markAsRead", id)
+	return repo.post(url, nil, nil)
+}
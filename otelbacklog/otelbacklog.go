@@ -0,0 +1,206 @@
+// Package otelbacklog instruments Backlog API calls with OpenTelemetry.
+//
+//	repo := backlog.NewRepository(subdomain, apiKey)
+//	repo.Use(otelbacklog.Middleware())
+//
+// Spans are children of the span in the context of the request,
+// which Repository.WithContext sets:
+//
+//	issue, err := repo.WithContext(ctx).FindIssue(1)
+package otelbacklog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yoheimiyamoto/backlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/yoheimiyamoto/backlog/otelbacklog"
+
+// Attribute keys
+const (
+	EndpointKey   = attribute.Key("backlog.endpoint")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures Middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider. The global one is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider. The global one is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators that inject the trace context into
+// the request headers. The global ones are used by default.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Middleware returns a backlog.Middleware that creates a client span per call,
+// injects the trace context into the request headers and records
+//   - backlog.client.requests: number of calls
+//   - backlog.client.duration: latency in seconds, until the response body is closed
+//   - backlog.client.ratelimit.remaining: X-RateLimit-Remaining of the last response
+//
+// The span also ends when the response body is closed.
+func Middleware(opts ...Option) backlog.Middleware {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter("backlog.client.requests",
+		metric.WithDescription("Number of Backlog API calls"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram("backlog.client.duration",
+		metric.WithDescription("Latency of Backlog API calls until the response body is closed"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	rateLimitRemaining, err := meter.Int64Gauge("backlog.client.ratelimit.remaining",
+		metric.WithDescription("Remaining Backlog API rate limit"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next backlog.Client) backlog.Client {
+		return backlog.ClientFunc(func(req *backlog.Request) (*backlog.Response, error) {
+			endpoint := EndpointTemplate(req.Path)
+			attrs := []attribute.KeyValue{
+				EndpointKey.String(endpoint),
+				MethodKey.String(req.Method),
+			}
+
+			parent := req.Context
+			if parent == nil {
+				parent = context.Background()
+			}
+			ctx, span := tracer.Start(parent, req.Method+" "+endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...))
+
+			// copy req so that the caller's request is left as it is
+			r := *req
+			r.Context = ctx
+			r.Header = req.Header.Clone()
+			if r.Header == nil {
+				r.Header = make(http.Header)
+			}
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+			start := time.Now()
+			res, err := next.Do(&r)
+
+			end := func() {
+				requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+				duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+				span.End()
+			}
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, backlog.RedactAPIKey(err.Error()))
+				end()
+				return res, err
+			}
+
+			attrs = append(attrs, StatusCodeKey.Int(res.StatusCode))
+			span.SetAttributes(StatusCodeKey.Int(res.StatusCode))
+			if res.StatusCode >= 400 {
+				span.SetStatus(codes.Error, strconv.Itoa(res.StatusCode))
+			}
+			if v, convErr := strconv.ParseInt(res.Header.Get("X-RateLimit-Remaining"), 10, 64); convErr == nil {
+				rateLimitRemaining.Record(ctx, v, metric.WithAttributes(EndpointKey.String(endpoint)))
+			}
+
+			res.Body = &body{ReadCloser: res.Body, end: end}
+
+			return res, err
+		})
+	}
+}
+
+// body ends the span and records the duration when it is closed.
+type body struct {
+	io.ReadCloser
+	end  func()
+	once sync.Once
+}
+
+func (b *body) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.end)
+	return err
+}
+
+// EndpointTemplate replaces IDs and keys in path with placeholders so that
+// calls to the same endpoint share one name, e.g.
+// "api/v2/issues/PRJ-1/comments" becomes "api/v2/issues/{issueIdOrKey}/comments".
+func EndpointTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if i > 0 && segments[i-1] == "files" && s == "metadata" {
+			// the rest is a directory path of shared files
+			return strings.Join(append(segments[:i+1], "{path}"), "/")
+		}
+		if _, err := strconv.Atoi(s); err == nil {
+			segments[i] = "{id}"
+			continue
+		}
+		if i == 0 {
+			continue
+		}
+		switch segments[i-1] {
+		case "projects":
+			segments[i] = "{projectIdOrKey}"
+		case "issues":
+			if s != "count" {
+				segments[i] = "{issueIdOrKey}"
+			}
+		case "repositories":
+			segments[i] = "{repoIdOrName}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
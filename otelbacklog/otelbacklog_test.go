@@ -0,0 +1,75 @@
+package otelbacklog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yoheimiyamoto/backlog"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddlewareSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var header http.Header
+	client := backlog.Chain(backlog.ClientFunc(func(req *backlog.Request) (*backlog.Response, error) {
+		header = req.Header
+		return &backlog.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}), Middleware(WithTracerProvider(tp), WithPropagators(propagation.TraceContext{})))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	req := &backlog.Request{Context: ctx, Method: http.MethodGet, Path: "api/v2/issues/PRJ-1"}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Header != nil {
+		t.Error("the caller's request was modified")
+	}
+	if header.Get("Traceparent") == "" {
+		t.Error("trace context is not injected")
+	}
+	if n := len(recorder.Ended()); n != 0 {
+		t.Fatalf("%d spans ended before the body was closed", n)
+	}
+
+	res.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want 2", len(spans))
+	}
+	span := spans[0]
+	if got, want := span.Name(), "GET api/v2/issues/{issueIdOrKey}"; got != want {
+		t.Errorf("name = %q, want %q", got, want)
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("span is not a child of the span in the request context")
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := map[string]string{
+		"api/v2/issues/PRJ-1/comments":                            "api/v2/issues/{issueIdOrKey}/comments",
+		"api/v2/issues/count":                                     "api/v2/issues/count",
+		"api/v2/projects/PRJ/git/repositories/app/pullRequests/3": "api/v2/projects/{projectIdOrKey}/git/repositories/{repoIdOrName}/pullRequests/{id}",
+		"api/v2/projects/PRJ/files/metadata/a/b":                  "api/v2/projects/{projectIdOrKey}/files/metadata/{path}",
+	}
+	for path, want := range tests {
+		if got := EndpointTemplate(path); got != want {
+			t.Errorf("EndpointTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
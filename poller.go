@@ -0,0 +1,297 @@
+package backlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CheckpointStore stores the ID of the last activity a Poller emitted.
+type CheckpointStore interface {
+	// Load returns 0 if nothing is stored yet.
+	Load() (int, error)
+	Save(id int) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory. It is lost on restart.
+type MemoryCheckpointStore struct {
+	mu sync.Mutex
+	id int
+}
+
+func (s *MemoryCheckpointStore) Load() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id, nil
+}
+
+func (s *MemoryCheckpointStore) Save(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = id
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in the file at Path.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func (s *FileCheckpointStore) Load() (int, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// Save writes to a temporary file and renames it so that a crash never leaves a broken checkpoint.
+func (s *FileCheckpointStore) Save(id int) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.WriteString(strconv.Itoa(id) + "\n")
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Poller polls activities and emits them as Webhook values,
+// for environments that cannot receive webhooks.
+//
+// Each activity is decoded in the same way as a webhook request, so handlers can be shared.
+// Issue is built from the activity content, which has fewer fields than the content of
+// an issue webhook: only ID, Summary, Description and Comment are set. Content has the
+// rest, such as the changes of an update. Fetch the issue for its current state.
+// An activity that cannot be decoded is passed to OnError and emitted with
+// RawActivityContent, so that it does not block the activities after it.
+//
+// Events are emitted at least once: the checkpoint is saved after the handler
+// succeeds, so an event whose handler failed is emitted again on the next poll.
+type Poller struct {
+	Repository *Repository
+	Store      CheckpointStore
+
+	// ProjectIDOrKey limits the activities to a project. Empty polls the whole space.
+	ProjectIDOrKey string
+
+	// Types limits the activity types. Empty emits all types.
+	Types []ActivityType
+
+	// Interval is the interval of Run. The default is one minute.
+	Interval time.Duration
+
+	// OnError is called with the errors Poll and Run recover from. The default logs them with slog.
+	OnError func(err error)
+}
+
+const (
+	pollerPageSize   = 100
+	pollerMaxBackoff = 15 * time.Minute
+)
+
+// Run polls every Interval until ctx is done.
+// Errors such as network failures and rate limiting do not stop it: they are
+// passed to OnError and the next poll is delayed, doubling up to 15 minutes.
+func (p *Poller) Run(ctx context.Context, handle func(*Webhook) error) error {
+	interval := p.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+	wait := interval
+	for {
+		err := p.Poll(ctx, handle)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			p.onError(err)
+			wait = nextBackoff(wait, interval)
+		default:
+			wait = interval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *Poller) onError(err error) {
+	if p.OnError != nil {
+		p.OnError(err)
+		return
+	}
+	slog.Error("backlog poll failed", slog.String("error", err.Error()))
+}
+
+// nextBackoff doubles wait after a failure, up to pollerMaxBackoff
+// or interval if it is longer.
+func nextBackoff(wait, interval time.Duration) time.Duration {
+	max := pollerMaxBackoff
+	if interval > max {
+		max = interval
+	}
+	wait *= 2
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// Poll emits the activities after the checkpoint in order and saves the checkpoint.
+// If nothing is checkpointed yet, it saves the latest activity and emits nothing,
+// so that the first run does not replay the whole history.
+// Requests are sent with ctx, and it stops between pages and events when ctx is done.
+func (p *Poller) Poll(ctx context.Context, handle func(*Webhook) error) error {
+	last, err := p.Store.Load()
+	if err != nil {
+		return errors.Wrap(err, "load checkpoint failed")
+	}
+
+	if last == 0 {
+		q := p.query()
+		q.SetCount(1)
+		q.SetOrder("desc")
+		ws, err := p.list(ctx, q)
+		if err != nil {
+			return errors.Wrap(err, "list activities failed")
+		}
+		if len(ws) == 0 {
+			return nil
+		}
+		return p.Store.Save(ws[0].ID)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		q := p.query()
+		q.SetMinID(last + 1)
+		q.SetCount(pollerPageSize)
+		q.SetOrder("asc")
+		ws, err := p.list(ctx, q)
+		if err != nil {
+			return errors.Wrap(err, "list activities failed")
+		}
+
+		for _, w := range ws {
+			if w.ID <= last {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			err = handle(w)
+			if err != nil {
+				return err
+			}
+
+			err = p.Store.Save(w.ID)
+			if err != nil {
+				return errors.Wrap(err, "save checkpoint failed")
+			}
+			last = w.ID
+		}
+
+		if len(ws) < pollerPageSize {
+			return nil
+		}
+	}
+}
+
+func (p *Poller) query() ActivityQuery {
+	q := NewActivityQuery()
+	for _, t := range p.Types {
+		q.SetActivityType(t)
+	}
+	return q
+}
+
+// list lists activities and decodes them as webhooks one by one.
+func (p *Poller) list(ctx context.Context, q ActivityQuery) ([]*Webhook, error) {
+	path := "api/v2/space/activities"
+	if p.ProjectIDOrKey != "" {
+		path = fmt.Sprintf("api/v2/projects/%s/activities", p.ProjectIDOrKey)
+	}
+
+	var raws []json.RawMessage
+	err := p.Repository.WithContext(ctx).get(path, url.Values(q), &raws)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := make([]*Webhook, len(raws))
+	for i, raw := range raws {
+		var w Webhook
+		decodeErr := json.Unmarshal(raw, &w)
+		if decodeErr != nil {
+			w, err = rawWebhook(raw)
+			if err != nil {
+				return nil, err
+			}
+			p.onError(errors.Wrap(decodeErr, fmt.Sprintf("decode activity %d failed", w.ID)))
+		}
+		ws[i] = &w
+	}
+
+	return ws, nil
+}
+
+// rawWebhook decodes an activity whose content cannot be decoded,
+// leaving the content as RawActivityContent.
+func rawWebhook(data json.RawMessage) (Webhook, error) {
+	var raw struct {
+		ID          int             `json:"id"`
+		Project     *Project        `json:"project"`
+		Type        ActivityType    `json:"type"`
+		Content     json.RawMessage `json:"content"`
+		CreatedUser *User           `json:"createdUser"`
+		Created     time.Time       `json:"created"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		ID:          raw.ID,
+		Project:     raw.Project,
+		Type:        raw.Type,
+		Issue:       &Issue{},
+		CreatedUser: raw.CreatedUser,
+		Created:     raw.Created,
+		Content:     RawActivityContent(raw.Content),
+	}, nil
+}
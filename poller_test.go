@@ -0,0 +1,206 @@
+package backlog
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testActivitiesJSON = `[
+	{
+		"id": 11,
+		"project": {"id": 10, "projectKey": "PRJ"},
+		"type": 1,
+		"content": {"id": 1, "key_id": 1, "summary": "first", "description": "created"},
+		"createdUser": {"id": 8}
+	},
+	{
+		"id": 12,
+		"project": {"id": 10, "projectKey": "PRJ"},
+		"type": 2,
+		"content": {
+			"id": 1, "key_id": 1, "summary": "second", "description": "updated",
+			"comment": {"id": 3, "content": "done"},
+			"changes": [{"field": "summary", "new_value": "second", "old_value": "first", "type": "standard"}]
+		},
+		"createdUser": {"id": 8}
+	},
+	{
+		"id": 13,
+		"project": {"id": 10, "projectKey": "PRJ"},
+		"type": 5,
+		"content": {"id": 4, "name": "Home", "content": "hello"},
+		"createdUser": {"id": 8}
+	}
+]`
+
+func TestPollerPoll(t *testing.T) {
+	var paths []string
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if got := r.URL.Query().Get("minId"); got != "11" {
+			t.Errorf("minId = %q, want %q", got, "11")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testActivitiesJSON))
+	})
+
+	store := &MemoryCheckpointStore{}
+	store.Save(10)
+	p := &Poller{Repository: repo, Store: store, ProjectIDOrKey: "PRJ"}
+
+	var got []*Webhook
+	err := p.Poll(context.Background(), func(w *Webhook) error {
+		got = append(got, w)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/api/v2/projects/PRJ/activities" {
+		t.Errorf("requests = %q, want only the activities", paths)
+	}
+	if len(got) != 3 {
+		t.Fatalf("emitted %d webhooks, want 3", len(got))
+	}
+	for _, w := range got {
+		if w.Issue == nil {
+			t.Errorf("webhook %d has no Issue", w.ID)
+		}
+	}
+	if got[0].Issue.Summary != "first" || got[1].Issue.Summary != "second" {
+		t.Errorf("summaries = %q, %q, want the ones at each event", got[0].Issue.Summary, got[1].Issue.Summary)
+	}
+	if got[1].Issue.Comment.Content != "done" {
+		t.Errorf("comment = %q, want %q", got[1].Issue.Comment.Content, "done")
+	}
+	c, ok := got[1].Content.(*IssueActivityContent)
+	if !ok || len(c.Changes) != 1 || c.Changes[0].NewValue != "second" {
+		t.Errorf("content = %#v, want the issue changes", got[1].Content)
+	}
+	if _, ok := got[2].Content.(*WikiActivityContent); !ok {
+		t.Errorf("content = %#v, want *WikiActivityContent", got[2].Content)
+	}
+	if id, _ := store.Load(); id != 13 {
+		t.Errorf("checkpoint = %d, want 13", id)
+	}
+}
+
+func TestPollerRunContinuesAfterErrors(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errors": [{"message": "rate limited"}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errs []error
+	p := &Poller{
+		Repository: repo,
+		Store:      &MemoryCheckpointStore{},
+		Interval:   time.Millisecond,
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+	}
+	handled := make(chan struct{})
+	go func() {
+		for {
+			mu.Lock()
+			n := calls
+			mu.Unlock()
+			if n >= 3 {
+				close(handled)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	done := make(chan error)
+	go func() {
+		done <- p.Run(ctx, func(*Webhook) error { return nil })
+	}()
+
+	select {
+	case <-handled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run stopped polling after an error")
+	}
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run returned %v, want %v", err, context.Canceled)
+	}
+	if len(errs) != 1 {
+		t.Errorf("OnError called %d times, want 1", len(errs))
+	}
+}
+
+func TestPollerPollNonIssueActivities(t *testing.T) {
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id": 21, "type": 11, "content": {"rev": 5, "comment": "fix build"}},
+			{"id": 22, "type": 15, "content": {"users": [{"id": 1}], "comment": ""}},
+			{"id": 23, "type": 2, "content": {"id": 1, "summary": "broken", "comment": "not an object"}},
+			{"id": 24, "type": 16, "content": {"users": [{"id": 1}], "comment": "bye"}}
+		]`))
+	})
+
+	store := &MemoryCheckpointStore{}
+	store.Save(20)
+	var errs []error
+	p := &Poller{
+		Repository: repo,
+		Store:      store,
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+	}
+
+	var got []*Webhook
+	err := p.Poll(context.Background(), func(w *Webhook) error {
+		got = append(got, w)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("emitted %d webhooks, want 4", len(got))
+	}
+	for _, w := range got {
+		if w.Issue == nil {
+			t.Errorf("webhook %d has no Issue", w.ID)
+		}
+	}
+	if _, ok := got[1].Content.(RawActivityContent); !ok {
+		t.Errorf("content = %#v, want RawActivityContent", got[1].Content)
+	}
+	if _, ok := got[2].Content.(RawActivityContent); !ok || got[2].Type != ActivityTypeIssueUpdated {
+		t.Errorf("undecodable activity = %#v, want RawActivityContent", got[2])
+	}
+	if len(errs) != 1 {
+		t.Errorf("OnError called %d times, want 1 for the undecodable activity", len(errs))
+	}
+	if id, _ := store.Load(); id != 24 {
+		t.Errorf("checkpoint = %d, want 24", id)
+	}
+}
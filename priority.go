@@ -0,0 +1,32 @@
+package backlog
+
+import "fmt"
+
+// Priority represents
+type Priority struct {
+	ID   *int    `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+type priorities []*Priority
+
+// findID returns the ID of the priority named name.
+func (ps priorities) findID(name string) (int, error) {
+	for _, p := range ps {
+		if p.Name != nil && *p.Name == name {
+			return *p.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("priority '%s' is not found", name)
+}
+
+// ListPriorities lists the priorities of the space.
+func (repo *Repository) ListPriorities() ([]*Priority, error) {
+	var ps []*Priority
+	err := repo.get("api/v2/priorities", nil, &ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,218 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type Project struct {
+	ID                                int    `json:"id"`
+	Key                               string `json:"projectKey"`
+	Name                              string `json:"name"`
+	ChartEnabled                      bool   `json:"chartEnabled"`
+	UseResolvedForChart               bool   `json:"useResolvedForChart"`
+	SubtaskingEnabled                 bool   `json:"subtaskingEnabled"`
+	ProjectLeaderCanEditProjectLeader bool   `json:"projectLeaderCanEditProjectLeader"`
+	UseWiki                           bool   `json:"useWiki"`
+	UseFileSharing                    bool   `json:"useFileSharing"`
+	UseWikiTreeView                   bool   `json:"useWikiTreeView"`
+	UseOriginalImageSizeAtWiki        bool   `json:"useOriginalImageSizeAtWiki"`
+	UseDevAttributes                  bool   `json:"useDevAttributes"`
+	TextFormattingRule                string `json:"textFormattingRule"` // "backlog" or "markdown"
+	Archived                          bool   `json:"archived"`
+	DisplayOrder                      int    `json:"displayOrder"`
+}
+
+func (p *Project) params() url.Values {
+	params := url.Values{
+		"name":                              {p.Name},
+		"key":                               {p.Key},
+		"chartEnabled":                      {strconv.FormatBool(p.ChartEnabled)},
+		"useResolvedForChart":               {strconv.FormatBool(p.UseResolvedForChart)},
+		"subtaskingEnabled":                 {strconv.FormatBool(p.SubtaskingEnabled)},
+		"projectLeaderCanEditProjectLeader": {strconv.FormatBool(p.ProjectLeaderCanEditProjectLeader)},
+		"useWiki":                           {strconv.FormatBool(p.UseWiki)},
+		"useFileSharing":                    {strconv.FormatBool(p.UseFileSharing)},
+		"useWikiTreeView":                   {strconv.FormatBool(p.UseWikiTreeView)},
+		"useOriginalImageSizeAtWiki":        {strconv.FormatBool(p.UseOriginalImageSizeAtWiki)},
+		"useDevAttributes":                  {strconv.FormatBool(p.UseDevAttributes)},
+	}
+	if p.TextFormattingRule != "" {
+		params.Set("textFormattingRule", p.TextFormattingRule)
+	}
+	return params
+}
+
+//+ListProjectsQuery
+
+type ListProjectsQuery url.Values
+
+func NewListProjectsQuery() ListProjectsQuery {
+	q := ListProjectsQuery{}
+	return q
+}
+
+// SetArchived filters projects by archived status.
+// Both archived and active projects are listed unless it is called.
+func (q ListProjectsQuery) SetArchived(archived bool) {
+	url.Values(q).Set("archived", strconv.FormatBool(archived))
+	return
+}
+
+// SetAll lists all projects in the space, not only the ones the user joins.
+// Only administrators can use it.
+func (q ListProjectsQuery) SetAll(all bool) {
+	url.Values(q).Set("all", strconv.FormatBool(all))
+	return
+}
+
+//-ListProjectsQuery
+
+func (repo *Repository) ListProjects(q ListProjectsQuery) ([]*Project, error) {
+	var projects []*Project
+	err := repo.get("api/v2/projects", url.Values(q), &projects)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// FindProject finds a project by its ID or key.
+func (repo *Repository) FindProject(idOrKey string) (*Project, error) {
+	url := fmt.Sprintf("api/v2/projects/%s", idOrKey)
+
+	var project Project
+	err := repo.get(url, nil, &project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (repo *Repository) CreateProject(project *Project) (*Project, error) {
+	var created Project
+	err := repo.post("api/v2/projects", project.params(), &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateProject updates the project with the same ID as project.
+func (repo *Repository) UpdateProject(project *Project) (*Project, error) {
+	url := fmt.Sprintf("api/v2/projects/%d", project.ID)
+
+	params := project.params()
+	params.Set("archived", strconv.FormatBool(project.Archived))
+
+	var updated Project
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (repo *Repository) DeleteProject(idOrKey string) (*Project, error) {
+	url := fmt.Sprintf("api/v2/projects/%s", idOrKey)
+
+	var deleted Project
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+//+project users
+
+// ListProjectUsers lists the members of a project.
+// If excludeGroupMembers is true, users who join only through a group are excluded.
+func (repo *Repository) ListProjectUsers(projectIDOrKey string, excludeGroupMembers bool) ([]*User, error) {
+	query := url.Values{"excludeGroupMembers": {strconv.FormatBool(excludeGroupMembers)}}
+	url := fmt.Sprintf("api/v2/projects/%s/users", projectIDOrKey)
+
+	var users []*User
+	err := repo.get(url, query, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (repo *Repository) AddProjectUser(projectIDOrKey string, userID int) (*User, error) {
+	params := url.Values{"userId": {strconv.Itoa(userID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/users", projectIDOrKey)
+
+	var user User
+	err := repo.post(url, params, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repo *Repository) DeleteProjectUser(projectIDOrKey string, userID int) (*User, error) {
+	params := url.Values{"userId": {strconv.Itoa(userID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/users", projectIDOrKey)
+
+	var user User
+	err := repo.delete(url, params, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+//-project users
+
+//+project administrators
+
+func (repo *Repository) ListProjectAdministrators(projectIDOrKey string) ([]*User, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/administrators", projectIDOrKey)
+
+	var users []*User
+	err := repo.get(url, nil, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (repo *Repository) AddProjectAdministrator(projectIDOrKey string, userID int) (*User, error) {
+	params := url.Values{"userId": {strconv.Itoa(userID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/administrators", projectIDOrKey)
+
+	var user User
+	err := repo.post(url, params, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repo *Repository) DeleteProjectAdministrator(projectIDOrKey string, userID int) (*User, error) {
+	params := url.Values{"userId": {strconv.Itoa(userID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/administrators", projectIDOrKey)
+
+	var user User
+	err := repo.delete(url, params, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+//-project administrators
@@ -0,0 +1,305 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PullRequest is a pull request of a Backlog git repository.
+type PullRequest struct {
+	ID           int                `json:"id,omitempty"`
+	ProjectID    int                `json:"projectId,omitempty"`
+	RepositoryID int                `json:"repositoryId,omitempty"`
+	Number       int                `json:"number,omitempty"`
+	Summary      string             `json:"summary,omitempty"`
+	Description  string             `json:"description,omitempty"`
+	Base         string             `json:"base,omitempty"`
+	Branch       string             `json:"branch,omitempty"`
+	Status       *PullRequestStatus `json:"status,omitempty"`
+	Assignee     *User              `json:"assignee,omitempty"`
+	Issue        *Issue             `json:"issue,omitempty"`
+	BaseCommit   string             `json:"baseCommit,omitempty"`
+	BranchCommit string             `json:"branchCommit,omitempty"`
+	CloseAt      *time.Time         `json:"closeAt,omitempty"`
+	MergeAt      *time.Time         `json:"mergeAt,omitempty"`
+	CreatedUser  *User              `json:"createdUser,omitempty"`
+	Created      time.Time          `json:"created,omitempty"`
+	UpdatedUser  *User              `json:"updatedUser,omitempty"`
+	Updated      time.Time          `json:"updated,omitempty"`
+}
+
+// Pull request statuses
+const (
+	PullRequestStatusOpen = iota + 1
+	PullRequestStatusClosed
+	PullRequestStatusMerged
+)
+
+type PullRequestStatus struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func (pr *PullRequest) params() url.Values {
+	params := url.Values{
+		"summary":     {pr.Summary},
+		"description": {pr.Description},
+	}
+	if pr.Issue != nil {
+		params.Set("issueId", strconv.Itoa(pr.Issue.ID))
+	}
+	if pr.Assignee != nil {
+		params.Set("assigneeId", strconv.Itoa(pr.Assignee.ID))
+	}
+	return params
+}
+
+func pullRequestsPath(projectIDOrKey, repoIDOrName string) string {
+	return fmt.Sprintf("api/v2/projects/%s/git/repositories/%s/pullRequests", projectIDOrKey, repoIDOrName)
+}
+
+//+PullRequestQuery
+
+type PullRequestQuery url.Values
+
+func NewPullRequestQuery() PullRequestQuery {
+	q := PullRequestQuery{}
+	return q
+}
+
+func (q PullRequestQuery) SetStatusID(id int) {
+	url.Values(q).Add("statusId[]", strconv.Itoa(id))
+	return
+}
+
+func (q PullRequestQuery) SetAssigneeID(id int) {
+	url.Values(q).Add("assigneeId[]", strconv.Itoa(id))
+	return
+}
+
+func (q PullRequestQuery) SetIssueID(id int) {
+	url.Values(q).Add("issueId[]", strconv.Itoa(id))
+	return
+}
+
+func (q PullRequestQuery) SetCreatedUserID(id int) {
+	url.Values(q).Add("createdUserId[]", strconv.Itoa(id))
+	return
+}
+
+func (q PullRequestQuery) SetOffset(offset int) {
+	url.Values(q).Set("offset", strconv.Itoa(offset))
+	return
+}
+
+// SetCount sets the number of pull requests to list, 1 to 100.
+func (q PullRequestQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+//-PullRequestQuery
+
+func (repo *Repository) ListPullRequests(projectIDOrKey, repoIDOrName string, q PullRequestQuery) ([]*PullRequest, error) {
+	var prs []*PullRequest
+	err := repo.get(pullRequestsPath(projectIDOrKey, repoIDOrName), url.Values(q), &prs)
+	if err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+func (repo *Repository) CountPullRequests(projectIDOrKey, repoIDOrName string, q PullRequestQuery) (int, error) {
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.get(pullRequestsPath(projectIDOrKey, repoIDOrName)+"/count", url.Values(q), &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+func (repo *Repository) FindPullRequest(projectIDOrKey, repoIDOrName string, number int) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/%d", pullRequestsPath(projectIDOrKey, repoIDOrName), number)
+
+	var pr PullRequest
+	err := repo.get(url, nil, &pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// CreatePullRequest opens a pull request from pr.Branch to pr.Base.
+// If pr.Issue is set, the pull request is linked to the issue.
+// notifiedUserIDs and attachmentIDs may be nil.
+func (repo *Repository) CreatePullRequest(projectIDOrKey, repoIDOrName string, pr *PullRequest, notifiedUserIDs, attachmentIDs []int) (*PullRequest, error) {
+	params := pr.params()
+	params.Set("base", pr.Base)
+	params.Set("branch", pr.Branch)
+	for _, id := range notifiedUserIDs {
+		params.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+	for _, id := range attachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
+
+	var created PullRequest
+	err := repo.post(pullRequestsPath(projectIDOrKey, repoIDOrName), params, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdatePullRequest updates the pull request with the same number as pr.
+// If comment is not empty, it is added as a comment of the update.
+func (repo *Repository) UpdatePullRequest(projectIDOrKey, repoIDOrName string, pr *PullRequest, comment string, notifiedUserIDs []int) (*PullRequest, error) {
+	params := pr.params()
+	if comment != "" {
+		params.Set("comment", comment)
+	}
+	for _, id := range notifiedUserIDs {
+		params.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("%s/%d", pullRequestsPath(projectIDOrKey, repoIDOrName), pr.Number)
+
+	var updated PullRequest
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+//+PullRequestCommentQuery
+
+type PullRequestCommentQuery url.Values
+
+func NewPullRequestCommentQuery() PullRequestCommentQuery {
+	q := PullRequestCommentQuery{}
+	return q
+}
+
+func (q PullRequestCommentQuery) SetMinID(id int) {
+	url.Values(q).Set("minId", strconv.Itoa(id))
+	return
+}
+
+func (q PullRequestCommentQuery) SetMaxID(id int) {
+	url.Values(q).Set("maxId", strconv.Itoa(id))
+	return
+}
+
+// SetCount sets the number of comments to list, 1 to 100.
+func (q PullRequestCommentQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+// SetOrder sets "asc" or "desc".
+func (q PullRequestCommentQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+//-PullRequestCommentQuery
+
+func (repo *Repository) ListPullRequestComments(projectIDOrKey, repoIDOrName string, number int, q PullRequestCommentQuery) ([]*Comment, error) {
+	query := url.Values(q)
+	url := fmt.Sprintf("%s/%d/comments", pullRequestsPath(projectIDOrKey, repoIDOrName), number)
+
+	var cs []*Comment
+	err := repo.get(url, query, &cs)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs, nil
+}
+
+func (repo *Repository) CountPullRequestComments(projectIDOrKey, repoIDOrName string, number int) (int, error) {
+	url := fmt.Sprintf("%s/%d/comments/count", pullRequestsPath(projectIDOrKey, repoIDOrName), number)
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.get(url, nil, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+func (repo *Repository) AddPullRequestComment(projectIDOrKey, repoIDOrName string, number int, content string, notifiedUserIDs []int) (*Comment, error) {
+	params := url.Values{"content": {content}}
+	for _, id := range notifiedUserIDs {
+		params.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("%s/%d/comments", pullRequestsPath(projectIDOrKey, repoIDOrName), number)
+
+	var c Comment
+	err := repo.post(url, params, &c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (repo *Repository) UpdatePullRequestComment(projectIDOrKey, repoIDOrName string, number int, comment *Comment) (*Comment, error) {
+	params := url.Values{"content": {comment.Content}}
+	url := fmt.Sprintf("%s/%d/comments/%d", pullRequestsPath(projectIDOrKey, repoIDOrName), number, comment.ID)
+
+	var updated Comment
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+//+pull request attachments
+
+func (repo *Repository) ListPullRequestAttachments(projectIDOrKey, repoIDOrName string, number int) ([]*Attachment, error) {
+	url := fmt.Sprintf("%s/%d/attachments", pullRequestsPath(projectIDOrKey, repoIDOrName), number)
+
+	var as []*Attachment
+	err := repo.get(url, nil, &as)
+	if err != nil {
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// DownloadPullRequestAttachment downloads a file attached to a pull request.
+// Close the returned File after reading.
+func (repo *Repository) DownloadPullRequestAttachment(projectIDOrKey, repoIDOrName string, number int, id int) (*File, error) {
+	url := fmt.Sprintf("%s/%d/attachments/%d", pullRequestsPath(projectIDOrKey, repoIDOrName), number, id)
+	return repo.download(url, nil)
+}
+
+func (repo *Repository) DeletePullRequestAttachment(projectIDOrKey, repoIDOrName string, number int, id int) (*Attachment, error) {
+	url := fmt.Sprintf("%s/%d/attachments/%d", pullRequestsPath(projectIDOrKey, repoIDOrName), number, id)
+
+	var deleted Attachment
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+//-pull request attachments
@@ -1,8 +1,13 @@
 package backlog
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
 	"net/url"
 	"strconv"
 
@@ -33,6 +38,7 @@ const (
 // Repository ...
 type Repository struct {
 	client Client
+	ctx    context.Context
 }
 
 func NewRepository(subdomain, apiKey string) *Repository {
@@ -40,33 +46,149 @@ func NewRepository(subdomain, apiKey string) *Repository {
 	return &Repository{client: c}
 }
 
-func (repo *Repository) FindIssue(id int) (*Issue, error) {
-	url := fmt.Sprintf("api/v2/issues/%d", id)
+// NewRepositoryWithClient returns a Repository that sends every request through c.
+func NewRepositoryWithClient(c Client) *Repository {
+	return &Repository{client: c}
+}
+
+// WithContext returns a copy of repo whose requests carry ctx,
+// so that they are canceled with ctx and traced under the span in ctx.
+//
+//	issue, err := repo.WithContext(ctx).FindIssue(1)
+func (repo *Repository) WithContext(ctx context.Context) *Repository {
+	r := *repo
+	r.ctx = ctx
+	return &r
+}
+
+func (repo *Repository) do(method, path string, query, body url.Values) (*Response, error) {
+	return repo.send(&Request{
+		Method: method,
+		Path:   path,
+		Query:  query,
+		Body:   body,
+	})
+}
+
+// send sends req and turns non-2xx responses into errors.
+func (repo *Repository) send(req *Request) (*Response, error) {
+	if req.Context == nil {
+		req.Context = repo.ctx
+	}
 
-	data, err := repo.client.get(url, nil)
+	res, err := repo.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	var issue Issue
-	err = json.Unmarshal(data, &issue)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		defer res.Body.Close()
+		data, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(string(data))
+	}
+
+	return res, nil
+}
+
+// decode decodes the JSON body of res into v and closes the body.
+// If v is nil, the body is discarded.
+func decode(res *Response, v interface{}) error {
+	defer res.Body.Close()
+
+	if v == nil {
+		_, err := io.Copy(ioutil.Discard, res.Body)
+		return err
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func (repo *Repository) get(path string, query url.Values, v interface{}) error {
+	res, err := repo.do(http.MethodGet, path, query, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return decode(res, v)
+}
 
-	return &issue, nil
+func (repo *Repository) patch(path string, params url.Values, v interface{}) error {
+	res, err := repo.do(http.MethodPatch, path, nil, params)
+	if err != nil {
+		return err
+	}
+	return decode(res, v)
 }
 
-func (repo *Repository) FindIssueWithStringID(id string) (*Issue, error) {
-	url := fmt.Sprintf("api/v2/issues/%s", id)
+func (repo *Repository) put(path string, params url.Values, v interface{}) error {
+	res, err := repo.do(http.MethodPut, path, nil, params)
+	if err != nil {
+		return err
+	}
+	return decode(res, v)
+}
 
-	data, err := repo.client.get(url, nil)
+func (repo *Repository) post(path string, params url.Values, v interface{}) error {
+	res, err := repo.do(http.MethodPost, path, nil, params)
+	if err != nil {
+		return err
+	}
+	return decode(res, v)
+}
+
+func (repo *Repository) delete(path string, params url.Values, v interface{}) error {
+	res, err := repo.do(http.MethodDelete, path, nil, params)
+	if err != nil {
+		return err
+	}
+	return decode(res, v)
+}
+
+// upload posts file as multipart/form-data.
+func (repo *Repository) upload(path string, file *Upload, v interface{}) error {
+	res, err := repo.send(&Request{
+		Method: http.MethodPost,
+		Path:   path,
+		File:   file,
+	})
+	if err != nil {
+		return err
+	}
+	return decode(res, v)
+}
+
+// File is a file downloaded from Backlog.
+// Close it after reading.
+type File struct {
+	Name        string
+	ContentType string
+	io.ReadCloser
+}
+
+func (repo *Repository) download(path string, query url.Values) (*File, error) {
+	res, err := repo.do(http.MethodGet, path, query, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	f := File{
+		ContentType: res.Header.Get("Content-Type"),
+		ReadCloser:  res.Body,
+	}
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		f.Name = params["filename"]
+	}
+
+	return &f, nil
+}
+
+func (repo *Repository) FindIssue(id int) (*Issue, error) {
+	url := fmt.Sprintf("api/v2/issues/%d", id)
+
 	var issue Issue
-	err = json.Unmarshal(data, &issue)
+	err := repo.get(url, nil, &issue)
 	if err != nil {
 		return nil, err
 	}
@@ -74,14 +196,21 @@ func (repo *Repository) FindIssueWithStringID(id string) (*Issue, error) {
 	return &issue, nil
 }
 
-func (repo *Repository) SearchIssues(q SearchIssueQuery) ([]*Issue, error) {
-	data, err := repo.client.get("api/v2/issues", url.Values(q))
+func (repo *Repository) FindIssueWithStringID(id string) (*Issue, error) {
+	url := fmt.Sprintf("api/v2/issues/%s", id)
+
+	var issue Issue
+	err := repo.get(url, nil, &issue)
 	if err != nil {
 		return nil, err
 	}
 
+	return &issue, nil
+}
+
+func (repo *Repository) SearchIssues(q SearchIssueQuery) ([]*Issue, error) {
 	var issues []*Issue
-	err = json.Unmarshal(data, &issues)
+	err := repo.get("api/v2/issues", url.Values(q), &issues)
 	if err != nil {
 		return nil, err
 	}
@@ -112,70 +241,138 @@ func (q SearchIssueQuery) SetParentIssueID(id int) {
 	return
 }
 
-func (q SearchIssueQuery) SetSort(how string) SearchIssueQuery {
-	url.Values(q).Add("sort", how)
-	return q
+func (q SearchIssueQuery) SetIssueTypeID(id int) {
+	url.Values(q).Add("issueTypeId[]", strconv.Itoa(id))
+	return
 }
 
-//-SearchIssueQuery
+func (q SearchIssueQuery) SetCategoryID(id int) {
+	url.Values(q).Add("categoryId[]", strconv.Itoa(id))
+	return
+}
 
-func (repo *Repository) UpdateIssue(issue *Issue) error {
-	url := fmt.Sprintf("api/v2/issues/%d", issue.ID)
+func (q SearchIssueQuery) SetVersionID(id int) {
+	url.Values(q).Add("versionId[]", strconv.Itoa(id))
+	return
+}
 
-	property, err := repo.getCustomFieldProperty(issue.ProjectID)
-	if err != nil {
-		return errors.Wrap(err, "get custom field property failed")
-	}
+func (q SearchIssueQuery) SetMilestoneID(id int) {
+	url.Values(q).Add("milestoneId[]", strconv.Itoa(id))
+	return
+}
 
-	issueStatusItems, err := repo.getIssueStatusItems(issue.ProjectID)
-	if err != nil {
-		return errors.Wrap(err, "get issue status items failed")
-	}
+func (q SearchIssueQuery) SetStatusID(id int) {
+	url.Values(q).Add("statusId[]", strconv.Itoa(id))
+	return
+}
 
-	params, err := issue.params(property, issueStatusItems)
-	if err != nil {
-		return errors.Wrap(err, "create params failed")
-	}
-	// log.Printf("params: %v", params)
+func (q SearchIssueQuery) SetPriorityID(id int) {
+	url.Values(q).Add("priorityId[]", strconv.Itoa(id))
+	return
+}
 
-	_, err = repo.client.put(url, params)
-	if err != nil {
-		return err
-	}
+func (q SearchIssueQuery) SetAssigneeID(id int) {
+	url.Values(q).Add("assigneeId[]", strconv.Itoa(id))
+	return
+}
 
-	return nil
+func (q SearchIssueQuery) SetCreatedUserID(id int) {
+	url.Values(q).Add("createdUserId[]", strconv.Itoa(id))
+	return
+}
+
+func (q SearchIssueQuery) SetResolutionID(id int) {
+	url.Values(q).Add("resolutionId[]", strconv.Itoa(id))
+	return
+}
+
+func (q SearchIssueQuery) SetParentChild(t ParentChildType) {
+	url.Values(q).Set("parentChild", strconv.Itoa(int(t)))
+	return
 }
 
-func (repo *Repository) getCustomFieldProperty(projectID int) (customFieldProperties, error) {
-	url := fmt.Sprintf("api/v2/projects/%d/customFields", projectID)
+func (q SearchIssueQuery) SetKeyword(keyword string) {
+	url.Values(q).Set("keyword", keyword)
+	return
+}
+
+// SetCustomFieldText filters text, sentence and number custom fields by value.
+func (q SearchIssueQuery) SetCustomFieldText(fieldID int, value string) {
+	url.Values(q).Set(fmt.Sprintf("customField_%d", fieldID), value)
+	return
+}
+
+// SetCustomFieldItemID filters list, checkbox and radio custom fields by item ID.
+// Call it more than once to match any of the items.
+func (q SearchIssueQuery) SetCustomFieldItemID(fieldID int, itemID int) {
+	url.Values(q).Add(fmt.Sprintf("customField_%d[]", fieldID), strconv.Itoa(itemID))
+	return
+}
+
+func (q SearchIssueQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+func (q SearchIssueQuery) SetOffset(offset int) {
+	url.Values(q).Set("offset", strconv.Itoa(offset))
+	return
+}
 
-	data, err := repo.client.get(url, nil)
+func (q SearchIssueQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+func (q SearchIssueQuery) SetSort(how string) SearchIssueQuery {
+	url.Values(q).Add("sort", how)
+	return q
+}
+
+//-SearchIssueQuery
+
+// CreateIssue creates issue in the project of issue.ProjectID.
+// IssueType, Priority, Categories, Versions and Milestones can be given by name,
+// and Assignee by mail address, user ID or name.
+func (repo *Repository) CreateIssue(issue *Issue) (*Issue, error) {
+	params, err := issue.params(repo.newIssueMetadata(issue.ProjectID))
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "create params failed")
+	}
+	params.Set("projectId", strconv.Itoa(issue.ProjectID))
+	params.Del("statusId") // new issues are always open
+	if issue.Assignee == nil {
+		params.Del("assigneeId")
+	}
+	if issue.ParentIssueID != 0 {
+		params.Set("parentIssueId", strconv.Itoa(issue.ParentIssueID))
 	}
 
-	var ps customFieldProperties
-	err = json.Unmarshal(data, &ps)
+	var created Issue
+	err = repo.post("api/v2/issues", params, &created)
 	if err != nil {
 		return nil, err
 	}
 
-	return ps, nil
+	return &created, nil
 }
 
-func (repo *Repository) getIssueStatusItems(projectID int) ([]*issueStatusItem, error) {
-	url := fmt.Sprintf("api/v2/projects/%d/statuses", projectID)
+// UpdateIssue updates issue. Like CreateIssue, the fields can be given by name.
+// IssueType, Priority and Resolution with both ID and name must agree: to change
+// one of them on a loaded issue, set the new ID or name and clear the other.
+func (repo *Repository) UpdateIssue(issue *Issue) error {
+	url := fmt.Sprintf("api/v2/issues/%d", issue.ID)
 
-	data, err := repo.client.get(url, nil)
+	params, err := issue.params(repo.newIssueMetadata(issue.ProjectID))
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "create params failed")
 	}
+	// log.Printf("params: %v", params)
 
-	var items []*issueStatusItem
-	err = json.Unmarshal(data, &items)
+	err = repo.patch(url, params, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return items, nil
+	return nil
 }
@@ -0,0 +1,31 @@
+package backlog
+
+import "fmt"
+
+type Resolution struct {
+	ID   *int    `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+type resolutions []*Resolution
+
+// findID returns the ID of the resolution named name.
+func (rs resolutions) findID(name string) (int, error) {
+	for _, r := range rs {
+		if r.Name != nil && *r.Name == name {
+			return *r.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("resolution '%s' is not found", name)
+}
+
+// ListResolutions lists the resolutions of the space.
+func (repo *Repository) ListResolutions() ([]*Resolution, error) {
+	var rs []*Resolution
+	err := repo.get("api/v2/resolutions", nil, &rs)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
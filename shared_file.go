@@ -0,0 +1,112 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SharedFile struct {
+	ID          int       `json:"id,omitempty"`
+	Type        string    `json:"type,omitempty"` // "file" or "directory"
+	Directory   string    `json:"dir,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	CreatedUser *User     `json:"createdUser,omitempty"`
+	Created     time.Time `json:"created,omitempty"`
+	UpdatedUser *User     `json:"updatedUser,omitempty"`
+	Updated     time.Time `json:"updated,omitempty"`
+}
+
+//+ListSharedFilesQuery
+
+type ListSharedFilesQuery url.Values
+
+func NewListSharedFilesQuery() ListSharedFilesQuery {
+	q := ListSharedFilesQuery{}
+	return q
+}
+
+// SetOrder sets "asc" or "desc".
+func (q ListSharedFilesQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+func (q ListSharedFilesQuery) SetOffset(offset int) {
+	url.Values(q).Set("offset", strconv.Itoa(offset))
+	return
+}
+
+// SetCount sets the number of files to list, 1 to 1000.
+func (q ListSharedFilesQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+//-ListSharedFilesQuery
+
+// ListSharedFiles lists the files and directories in dir of the project's shared files.
+// dir is a slash-separated path such as "/docs/specs"; "/" is the root.
+func (repo *Repository) ListSharedFiles(projectIDOrKey string, dir string, q ListSharedFilesQuery) ([]*SharedFile, error) {
+	query := url.Values(q)
+	url := fmt.Sprintf("api/v2/projects/%s/files/metadata/%s", projectIDOrKey, strings.TrimPrefix(dir, "/"))
+
+	var fs []*SharedFile
+	err := repo.get(url, query, &fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+// DownloadSharedFile downloads a shared file.
+// Close the returned File after reading.
+func (repo *Repository) DownloadSharedFile(projectIDOrKey string, id int) (*File, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/files/%d", projectIDOrKey, id)
+	return repo.download(url, nil)
+}
+
+func (repo *Repository) ListIssueSharedFiles(issueIDOrKey string) ([]*SharedFile, error) {
+	url := fmt.Sprintf("api/v2/issues/%s/sharedFiles", issueIDOrKey)
+
+	var fs []*SharedFile
+	err := repo.get(url, nil, &fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+// LinkSharedFiles links shared files to an issue and returns the linked files.
+func (repo *Repository) LinkSharedFiles(issueIDOrKey string, fileIDs []int) ([]*SharedFile, error) {
+	params := url.Values{}
+	for _, id := range fileIDs {
+		params.Add("fileId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("api/v2/issues/%s/sharedFiles", issueIDOrKey)
+
+	var fs []*SharedFile
+	err := repo.post(url, params, &fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+func (repo *Repository) UnlinkSharedFile(issueIDOrKey string, fileID int) (*SharedFile, error) {
+	url := fmt.Sprintf("api/v2/issues/%s/sharedFiles/%d", issueIDOrKey, fileID)
+
+	var f SharedFile
+	err := repo.delete(url, nil, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
@@ -0,0 +1,144 @@
+package backlog
+
+import (
+	"net/url"
+	"time"
+)
+
+type Space struct {
+	SpaceKey           string    `json:"spaceKey,omitempty"`
+	Name               string    `json:"name,omitempty"`
+	OwnerID            int       `json:"ownerId,omitempty"`
+	Lang               string    `json:"lang,omitempty"`
+	Timezone           string    `json:"timezone,omitempty"`
+	ReportSendTime     string    `json:"reportSendTime,omitempty"`
+	TextFormattingRule string    `json:"textFormattingRule,omitempty"`
+	Created            time.Time `json:"created,omitempty"`
+	Updated            time.Time `json:"updated,omitempty"`
+}
+
+// SpaceNotification is the notice shown to all the users of the space.
+type SpaceNotification struct {
+	Content string    `json:"content,omitempty"`
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// DiskUsage is the disk usage of the space in bytes.
+type DiskUsage struct {
+	Capacity   int64               `json:"capacity"`
+	Issue      int64               `json:"issue"`
+	Wiki       int64               `json:"wiki"`
+	File       int64               `json:"file"`
+	Subversion int64               `json:"subversion"`
+	Git        int64               `json:"git"`
+	GitLFS     int64               `json:"gitLFS"`
+	Details    []*ProjectDiskUsage `json:"details"`
+}
+
+// Used returns the total bytes used in the space.
+func (d *DiskUsage) Used() int64 {
+	return d.Issue + d.Wiki + d.File + d.Subversion + d.Git + d.GitLFS
+}
+
+// ProjectDiskUsage is the disk usage of a project in bytes.
+type ProjectDiskUsage struct {
+	ProjectID  int   `json:"projectId"`
+	Issue      int64 `json:"issue"`
+	Wiki       int64 `json:"wiki"`
+	File       int64 `json:"file"`
+	Subversion int64 `json:"subversion"`
+	Git        int64 `json:"git"`
+	GitLFS     int64 `json:"gitLFS"`
+}
+
+// Used returns the total bytes used in the project.
+func (d *ProjectDiskUsage) Used() int64 {
+	return d.Issue + d.Wiki + d.File + d.Subversion + d.Git + d.GitLFS
+}
+
+// Licence is the licence of the space. Limits are 0 if unlimited.
+type Licence struct {
+	Active                            bool      `json:"active"`
+	LicenceTypeID                     int       `json:"licenceTypeId"`
+	StartedOn                         time.Time `json:"startedOn"`
+	LimitDate                         time.Time `json:"limitDate"`
+	StorageLimit                      int64     `json:"storageLimit"`
+	UserLimit                         int       `json:"userLimit"`
+	ProjectLimit                      int       `json:"projectLimit"`
+	IssueLimit                        int       `json:"issueLimit"`
+	AttachmentLimit                   int64     `json:"attachmentLimit"`
+	AttachmentLimitPerFile            int64     `json:"attachmentLimitPerFile"`
+	AttachmentNumLimit                int       `json:"attachmentNumLimit"`
+	WikiAttachmentLimitPerFile        int64     `json:"wikiAttachmentLimitPerFile"`
+	WikiAttachmentNumLimit            int       `json:"wikiAttachmentNumLimit"`
+	PullRequestAttachmentLimitPerFile int64     `json:"pullRequestAttachmentLimitPerFile"`
+	PullRequestAttachmentNumLimit     int       `json:"pullRequestAttachmentNumLimit"`
+	Git                               bool      `json:"git"`
+	Subversion                        bool      `json:"subversion"`
+	Gantt                             bool      `json:"gantt"`
+	Burndown                          bool      `json:"burndown"`
+	FileSharing                       bool      `json:"fileSharing"`
+	WikiAttachment                    bool      `json:"wikiAttachment"`
+	ParentChildIssue                  bool      `json:"parentChildIssue"`
+	NulabAccount                      bool      `json:"nulabAccount"`
+}
+
+func (repo *Repository) FindSpace() (*Space, error) {
+	var s Space
+	err := repo.get("api/v2/space", nil, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// DownloadSpaceLogo downloads the logo image of the space.
+// Close the returned File after reading.
+func (repo *Repository) DownloadSpaceLogo() (*File, error) {
+	return repo.download("api/v2/space/image", nil)
+}
+
+func (repo *Repository) FindSpaceNotification() (*SpaceNotification, error) {
+	var n SpaceNotification
+	err := repo.get("api/v2/space/notification", nil, &n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+func (repo *Repository) UpdateSpaceNotification(content string) (*SpaceNotification, error) {
+	params := url.Values{"content": {content}}
+
+	var n SpaceNotification
+	err := repo.put("api/v2/space/notification", params, &n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+// FindDiskUsage returns the disk usage of the space and of each project.
+// Only administrators can use it.
+func (repo *Repository) FindDiskUsage() (*DiskUsage, error) {
+	var d DiskUsage
+	err := repo.get("api/v2/space/diskUsage", nil, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (repo *Repository) FindLicence() (*Licence, error) {
+	var l Licence
+	err := repo.get("api/v2/space/licence", nil, &l)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
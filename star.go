@@ -0,0 +1,117 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Star struct {
+	ID        int       `json:"id,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Created   time.Time `json:"created,omitempty"`
+	Presenter *User     `json:"presenter,omitempty"`
+}
+
+func (repo *Repository) addStar(key string, id int) error {
+	params := url.Values{key: {strconv.Itoa(id)}}
+	return repo.post("api/v2/stars", params, nil)
+}
+
+func (repo *Repository) StarIssue(issueID int) error {
+	return repo.addStar("issueId", issueID)
+}
+
+func (repo *Repository) StarComment(commentID int) error {
+	return repo.addStar("commentId", commentID)
+}
+
+func (repo *Repository) StarWiki(wikiID int) error {
+	return repo.addStar("wikiId", wikiID)
+}
+
+func (repo *Repository) StarPullRequest(pullRequestID int) error {
+	return repo.addStar("pullRequestId", pullRequestID)
+}
+
+func (repo *Repository) StarPullRequestComment(commentID int) error {
+	return repo.addStar("pullRequestCommentId", commentID)
+}
+
+func (repo *Repository) RemoveStar(starID int) error {
+	url := fmt.Sprintf("api/v2/stars/%d", starID)
+	return repo.delete(url, nil, nil)
+}
+
+//+StarQuery
+
+type StarQuery url.Values
+
+func NewStarQuery() StarQuery {
+	q := StarQuery{}
+	return q
+}
+
+func (q StarQuery) SetMinID(id int) {
+	url.Values(q).Set("minId", strconv.Itoa(id))
+	return
+}
+
+func (q StarQuery) SetMaxID(id int) {
+	url.Values(q).Set("maxId", strconv.Itoa(id))
+	return
+}
+
+// SetCount sets the number of stars to list, 1 to 100.
+func (q StarQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+// SetOrder sets "asc" or "desc".
+func (q StarQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+//-StarQuery
+
+// ListUserStars lists the stars a user received.
+func (repo *Repository) ListUserStars(userID int, q StarQuery) ([]*Star, error) {
+	query := url.Values(q)
+	url := fmt.Sprintf("api/v2/users/%d/stars", userID)
+
+	var stars []*Star
+	err := repo.get(url, query, &stars)
+	if err != nil {
+		return nil, err
+	}
+
+	return stars, nil
+}
+
+// CountUserStars counts the stars a user received from since to until.
+// Zero since or until means no limit.
+func (repo *Repository) CountUserStars(userID int, since, until time.Time) (int, error) {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.Format("2006-01-02"))
+	}
+	if !until.IsZero() {
+		query.Set("until", until.Format("2006-01-02"))
+	}
+	url := fmt.Sprintf("api/v2/users/%d/stars/count", userID)
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.get(url, query, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
@@ -0,0 +1,121 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Colors available for statuses
+const (
+	StatusColorRed      = "#ea2c00"
+	StatusColorSalmon   = "#e87758"
+	StatusColorPink     = "#e07b9a"
+	StatusColorLavender = "#868cb7"
+	StatusColorBlue     = "#3b9dbd"
+	StatusColorGreen    = "#4caf93"
+	StatusColorYellow   = "#b0be3c"
+	StatusColorOrange   = "#eda62a"
+	StatusColorCrimson  = "#f42858"
+	StatusColorBlack    = "#393939"
+)
+
+// Status is a status of issues in a project.
+type Status struct {
+	ID           int    `json:"id,omitempty"`
+	ProjectID    int    `json:"projectId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Color        string `json:"color,omitempty"`
+	DisplayOrder int    `json:"displayOrder,omitempty"`
+}
+
+func (s *Status) params() url.Values {
+	return url.Values{
+		"name":  {s.Name},
+		"color": {s.Color},
+	}
+}
+
+type statuses []*Status
+
+// findID returns the ID of the status named name.
+func (ss statuses) findID(name string) (int, error) {
+	for _, s := range ss {
+		if s.Name == name {
+			return s.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("status '%s' is not found", name)
+}
+
+func (repo *Repository) ListStatuses(projectIDOrKey string) ([]*Status, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/statuses", projectIDOrKey)
+
+	var ss []*Status
+	err := repo.get(url, nil, &ss)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+// CreateStatus creates a custom status in the project.
+// Color must be one of the StatusColor constants.
+func (repo *Repository) CreateStatus(projectIDOrKey string, status *Status) (*Status, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/statuses", projectIDOrKey)
+
+	var created Status
+	err := repo.post(url, status.params(), &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateStatus updates the status with the same ID as status.
+func (repo *Repository) UpdateStatus(projectIDOrKey string, status *Status) (*Status, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/statuses/%d", projectIDOrKey, status.ID)
+
+	var updated Status
+	err := repo.patch(url, status.params(), &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteStatus deletes a custom status.
+// Issues in the deleted status are changed to the status of substituteID.
+func (repo *Repository) DeleteStatus(projectIDOrKey string, id int, substituteID int) (*Status, error) {
+	params := url.Values{"substituteStatusId": {strconv.Itoa(substituteID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/statuses/%d", projectIDOrKey, id)
+
+	var deleted Status
+	err := repo.delete(url, params, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+// ReorderStatuses sorts the statuses of the project in the order of ids.
+// ids must contain all the statuses of the project.
+func (repo *Repository) ReorderStatuses(projectIDOrKey string, ids []int) ([]*Status, error) {
+	params := url.Values{}
+	for _, id := range ids {
+		params.Add("statusId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("api/v2/projects/%s/statuses/updateDisplayOrder", projectIDOrKey)
+
+	var ss []*Status
+	err := repo.patch(url, params, &ss)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
@@ -0,0 +1,179 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Team struct {
+	ID           int       `json:"id,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Members      []*User   `json:"members,omitempty"`
+	DisplayOrder int       `json:"displayOrder,omitempty"`
+	CreatedUser  *User     `json:"createdUser,omitempty"`
+	Created      time.Time `json:"created,omitempty"`
+	UpdatedUser  *User     `json:"updatedUser,omitempty"`
+	Updated      time.Time `json:"updated,omitempty"`
+}
+
+func (t *Team) params() url.Values {
+	params := url.Values{"name": {t.Name}}
+	for _, m := range t.Members {
+		params.Add("members[]", strconv.Itoa(m.ID))
+	}
+	return params
+}
+
+//+ListTeamsQuery
+
+type ListTeamsQuery url.Values
+
+func NewListTeamsQuery() ListTeamsQuery {
+	q := ListTeamsQuery{}
+	return q
+}
+
+// SetOrder sets "asc" or "desc".
+func (q ListTeamsQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+func (q ListTeamsQuery) SetOffset(offset int) {
+	url.Values(q).Set("offset", strconv.Itoa(offset))
+	return
+}
+
+// SetCount sets the number of teams to list, 1 to 100.
+func (q ListTeamsQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+//-ListTeamsQuery
+
+func (repo *Repository) ListTeams(q ListTeamsQuery) ([]*Team, error) {
+	var ts []*Team
+	err := repo.get("api/v2/teams", url.Values(q), &ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+func (repo *Repository) FindTeam(id int) (*Team, error) {
+	url := fmt.Sprintf("api/v2/teams/%d", id)
+
+	var t Team
+	err := repo.get(url, nil, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// CreateTeam creates a team with team.Members. Only the IDs of the members are used.
+func (repo *Repository) CreateTeam(team *Team) (*Team, error) {
+	var created Team
+	err := repo.post("api/v2/teams", team.params(), &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateTeam updates the team with the same ID as team.
+// The members are replaced with team.Members.
+func (repo *Repository) UpdateTeam(team *Team) (*Team, error) {
+	url := fmt.Sprintf("api/v2/teams/%d", team.ID)
+
+	var updated Team
+	err := repo.patch(url, team.params(), &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// AddTeamMember adds a user to a team, keeping the current members.
+func (repo *Repository) AddTeamMember(teamID int, userID int) (*Team, error) {
+	t, err := repo.FindTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range t.Members {
+		if m.ID == userID {
+			return t, nil
+		}
+	}
+	t.Members = append(t.Members, &User{ID: userID})
+
+	return repo.UpdateTeam(t)
+}
+
+func (repo *Repository) DeleteTeam(id int) (*Team, error) {
+	url := fmt.Sprintf("api/v2/teams/%d", id)
+
+	var deleted Team
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+// DownloadTeamIcon downloads the icon image of a team.
+// Close the returned File after reading.
+func (repo *Repository) DownloadTeamIcon(id int) (*File, error) {
+	return repo.download(fmt.Sprintf("api/v2/teams/%d/icon", id), nil)
+}
+
+//+project teams
+
+func (repo *Repository) ListProjectTeams(projectIDOrKey string) ([]*Team, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/teams", projectIDOrKey)
+
+	var ts []*Team
+	err := repo.get(url, nil, &ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+func (repo *Repository) AddProjectTeam(projectIDOrKey string, teamID int) (*Team, error) {
+	params := url.Values{"teamId": {strconv.Itoa(teamID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/teams", projectIDOrKey)
+
+	var t Team
+	err := repo.post(url, params, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (repo *Repository) DeleteProjectTeam(projectIDOrKey string, teamID int) (*Team, error) {
+	params := url.Values{"teamId": {strconv.Itoa(teamID)}}
+	url := fmt.Sprintf("api/v2/projects/%s/teams", projectIDOrKey)
+
+	var t Team
+	err := repo.delete(url, params, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+//-project teams
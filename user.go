@@ -0,0 +1,111 @@
+package backlog
+
+import "fmt"
+
+// Role types of users
+const (
+	RoleTypeAdministrator = iota + 1
+	RoleTypeNormalUser
+	RoleTypeReporter
+	RoleTypeViewer
+	RoleTypeGuestReporter
+	RoleTypeGuestViewer
+)
+
+type User struct {
+	ID           int           `json:"id,omitempty"`
+	UserID       string        `json:"userId,omitempty"`
+	Name         string        `json:"name,omitempty"`
+	RoleType     int           `json:"roleType,omitempty"`
+	Lang         string        `json:"lang,omitempty"`
+	MailAddress  string        `json:"mailAddress,omitempty"`
+	NulabAccount *NulabAccount `json:"nulabAccount,omitempty"`
+}
+
+// NulabAccount is the Nulab account linked to a Backlog user.
+type NulabAccount struct {
+	NulabID  string `json:"nulabId,omitempty"`
+	Name     string `json:"name,omitempty"`
+	UniqueID string `json:"uniqueId,omitempty"`
+}
+
+type users []*User
+
+// find returns the user whose mail address, user ID or name is key.
+func (us users) find(key string) (*User, error) {
+	var found *User
+
+	for _, u := range us {
+		if u.MailAddress == key || u.UserID == key || u.Name == key {
+			if found != nil && found.ID != u.ID {
+				return nil, fmt.Errorf("user '%s' is ambiguous", key)
+			}
+			found = u
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("user '%s' is not found", key)
+	}
+
+	return found, nil
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Myself returns the user of the API key.
+func (repo *Repository) Myself() (*User, error) {
+	var user User
+	err := repo.get("api/v2/users/myself", nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// ListUsers lists the users in the space.
+func (repo *Repository) ListUsers() ([]*User, error) {
+	var us []*User
+	err := repo.get("api/v2/users", nil, &us)
+	if err != nil {
+		return nil, err
+	}
+
+	return us, nil
+}
+
+func (repo *Repository) FindUser(id int) (*User, error) {
+	url := fmt.Sprintf("api/v2/users/%d", id)
+
+	var user User
+	err := repo.get(url, nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// LookupUser finds a user in the space by mail address, user ID or name.
+func (repo *Repository) LookupUser(key string) (*User, error) {
+	us, err := repo.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	return users(us).find(key)
+}
+
+// DownloadUserIcon downloads the icon image of a user.
+// Close the returned File after reading.
+func (repo *Repository) DownloadUserIcon(id int) (*File, error) {
+	return repo.download(fmt.Sprintf("api/v2/users/%d/icon", id), nil)
+}
@@ -0,0 +1,127 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Version is a version or a milestone of a project.
+type Version struct {
+	ID             int       `json:"id,omitempty"`
+	ProjectID      int       `json:"projectId,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	StartDate      time.Time `json:"startDate,omitempty"`
+	ReleaseDueDate time.Time `json:"releaseDueDate,omitempty"`
+	Archived       bool      `json:"archived,omitempty"`
+	DisplayOrder   int       `json:"displayOrder,omitempty"`
+}
+
+func (v *Version) params() url.Values {
+	params := url.Values{
+		"name":        {v.Name},
+		"description": {v.Description},
+	}
+	if !v.StartDate.IsZero() {
+		params.Set("startDate", v.StartDate.Format("2006-01-02"))
+	}
+	if !v.ReleaseDueDate.IsZero() {
+		params.Set("releaseDueDate", v.ReleaseDueDate.Format("2006-01-02"))
+	}
+	return params
+}
+
+type versions []*Version
+
+// findID returns the ID of the version named name.
+func (vs versions) findID(name string) (int, error) {
+	for _, v := range vs {
+		if v.Name == name {
+			return v.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("version '%s' is not found", name)
+}
+
+// ListVersions lists the versions and milestones of the project.
+// Archived ones are included only if archived is true.
+func (repo *Repository) ListVersions(projectIDOrKey string, archived bool) ([]*Version, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/versions", projectIDOrKey)
+
+	var vs []*Version
+	err := repo.get(url, nil, &vs)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived {
+		return vs, nil
+	}
+
+	active := make([]*Version, 0, len(vs))
+	for _, v := range vs {
+		if !v.Archived {
+			active = append(active, v)
+		}
+	}
+
+	return active, nil
+}
+
+func (repo *Repository) CreateVersion(projectIDOrKey string, version *Version) (*Version, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/versions", projectIDOrKey)
+
+	var created Version
+	err := repo.post(url, version.params(), &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateVersion updates the version with the same ID as version, including its archived status.
+func (repo *Repository) UpdateVersion(projectIDOrKey string, version *Version) (*Version, error) {
+	params := version.params()
+	params.Set("archived", strconv.FormatBool(version.Archived))
+	url := fmt.Sprintf("api/v2/projects/%s/versions/%d", projectIDOrKey, version.ID)
+
+	var updated Version
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// ArchiveVersion archives a version, e.g. a milestone that has been shipped.
+func (repo *Repository) ArchiveVersion(projectIDOrKey string, id int) (*Version, error) {
+	vs, err := repo.ListVersions(projectIDOrKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range vs {
+		if v.ID == id {
+			v.Archived = true
+			return repo.UpdateVersion(projectIDOrKey, v)
+		}
+	}
+
+	return nil, fmt.Errorf("version %d is not found", id)
+}
+
+func (repo *Repository) DeleteVersion(projectIDOrKey string, id int) (*Version, error) {
+	url := fmt.Sprintf("api/v2/projects/%s/versions/%d", projectIDOrKey, id)
+
+	var deleted Version
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
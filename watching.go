@@ -0,0 +1,153 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Watching is an issue watched by a user.
+type Watching struct {
+	ID                  int       `json:"id,omitempty"`
+	ResourceAlreadyRead bool      `json:"resourceAlreadyRead,omitempty"`
+	Note                string    `json:"note,omitempty"`
+	Type                string    `json:"type,omitempty"`
+	Issue               *Issue    `json:"issue,omitempty"`
+	LastContentUpdated  time.Time `json:"lastContentUpdated,omitempty"`
+	Created             time.Time `json:"created,omitempty"`
+	Updated             time.Time `json:"updated,omitempty"`
+}
+
+//+WatchingQuery
+
+type WatchingQuery url.Values
+
+func NewWatchingQuery() WatchingQuery {
+	q := WatchingQuery{}
+	return q
+}
+
+// SetOrder sets "asc" or "desc".
+func (q WatchingQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+// SetSort sets "created", "updated" or "issueUpdated".
+func (q WatchingQuery) SetSort(how string) {
+	url.Values(q).Set("sort", how)
+	return
+}
+
+// SetCount sets the number of watchings to list, 1 to 100.
+func (q WatchingQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+func (q WatchingQuery) SetOffset(offset int) {
+	url.Values(q).Set("offset", strconv.Itoa(offset))
+	return
+}
+
+func (q WatchingQuery) SetResourceAlreadyRead(read bool) {
+	url.Values(q).Set("resourceAlreadyRead", strconv.FormatBool(read))
+	return
+}
+
+func (q WatchingQuery) SetIssueID(id int) {
+	url.Values(q).Add("issueId[]", strconv.Itoa(id))
+	return
+}
+
+//-WatchingQuery
+
+func (repo *Repository) ListWatchings(userID int, q WatchingQuery) ([]*Watching, error) {
+	query := url.Values(q)
+	url := fmt.Sprintf("api/v2/users/%d/watchings", userID)
+
+	var ws []*Watching
+	err := repo.get(url, query, &ws)
+	if err != nil {
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+// CountWatchings counts the watchings of a user.
+// SetResourceAlreadyRead of q filters them by whether the issues are read.
+func (repo *Repository) CountWatchings(userID int, q WatchingQuery) (int, error) {
+	query := url.Values(q)
+	url := fmt.Sprintf("api/v2/users/%d/watchings/count", userID)
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.get(url, query, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+func (repo *Repository) FindWatching(id int) (*Watching, error) {
+	url := fmt.Sprintf("api/v2/watchings/%d", id)
+
+	var w Watching
+	err := repo.get(url, nil, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// AddWatching makes the user of the API key watch an issue.
+func (repo *Repository) AddWatching(issueIDOrKey string, note string) (*Watching, error) {
+	params := url.Values{
+		"issueIdOrKey": {issueIDOrKey},
+		"note":         {note},
+	}
+
+	var w Watching
+	err := repo.post("api/v2/watchings", params, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// UpdateWatching updates the note of a watching.
+func (repo *Repository) UpdateWatching(id int, note string) (*Watching, error) {
+	params := url.Values{"note": {note}}
+	url := fmt.Sprintf("api/v2/watchings/%d", id)
+
+	var w Watching
+	err := repo.patch(url, params, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (repo *Repository) DeleteWatching(id int) (*Watching, error) {
+	url := fmt.Sprintf("api/v2/watchings/%d", id)
+
+	var w Watching
+	err := repo.delete(url, nil, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (repo *Repository) MarkWatchingAsRead(id int) error {
+	url := fmt.Sprintf("api/v2/watchings/%d/markAsRead", id)
+	return repo.post(url, nil, nil)
+}
@@ -6,39 +6,45 @@ import (
 )
 
 type Webhook struct {
-	ID          int       `json:"id"`
-	Project     *Project  `json:"project"`
-	Issue       *Issue    `json:"content"`
-	CreatedUser *User     `json:"createdUser"`
-	Created     time.Time `json:"created"`
+	ID          int          `json:"id"`
+	Project     *Project     `json:"project"`
+	Type        ActivityType `json:"type"`
+	Issue       *Issue       `json:"content"` // empty for non-issue events
+	CreatedUser *User        `json:"createdUser"`
+	Created     time.Time    `json:"created"`
+
+	// Content is the content decoded by Type, as in Activity.Content.
+	// Use it for the fields Issue does not have, such as the changes of an update
+	// or the content of non-issue events.
+	Content ActivityContent `json:"-"`
 }
 
 func (w *Webhook) UnmarshalJSON(data []byte) error {
 
 	type RawIssue struct {
-		ID             int             `json:"id"`
-		ProjectID      int             `json:"projectId"`
-		IssueKey       string          `json:"issueKey"`
-		KeyID          int             `json:"keyId"`
-		IssueType      IssueType       `json:"issueType"`
-		Summary        string          `json:"summary"`
-		Description    string          `json:"description"` // 詳細
-		Resolution     Resolution      `json:"resolution"`
-		Priority       Priority        `json:"priority"`
-		Status         issueStatusItem `json:"status"`
-		Assignee       Assignee        `json:"assignee"`
-		Categories     []*Category     `json:"category"`
-		Versions       []*Version      `json:"versions"`
-		Milestones     []*Version      `json:"milestone"`
-		StartDate      Date            `json:"startDate"`
-		DueDate        Date            `json:"dueDate"`
-		EstimatedHours int             `json:"estimatedHours"`
-		ActualHours    int             `json:"actualHours"`
-		ParentIssueID  int             `json:"parentIssueId"`
-		CreatedUser    *User           `json:"createdUser"`
-		Created        time.Time       `json:"created"`
-		UpdatedUser    *User           `json:"updatedUser"`
-		Updated        time.Time       `json:"updated"`
+		ID             int         `json:"id"`
+		ProjectID      int         `json:"projectId"`
+		IssueKey       string      `json:"issueKey"`
+		KeyID          int         `json:"keyId"`
+		IssueType      IssueType   `json:"issueType"`
+		Summary        string      `json:"summary"`
+		Description    string      `json:"description"` // 詳細
+		Resolution     Resolution  `json:"resolution"`
+		Priority       Priority    `json:"priority"`
+		Status         Status      `json:"status"`
+		Assignee       *User       `json:"assignee"`
+		Categories     []*Category `json:"category"`
+		Versions       []*Version  `json:"versions"`
+		Milestones     []*Version  `json:"milestone"`
+		StartDate      Date        `json:"startDate"`
+		DueDate        Date        `json:"dueDate"`
+		EstimatedHours int         `json:"estimatedHours"`
+		ActualHours    int         `json:"actualHours"`
+		ParentIssueID  int         `json:"parentIssueId"`
+		CreatedUser    *User       `json:"createdUser"`
+		Created        time.Time   `json:"created"`
+		UpdatedUser    *User       `json:"updatedUser"`
+		Updated        time.Time   `json:"updated"`
 		// CustomFields   []*RawCustomField `json:"customFields"` // customFieldsを取得できない
 		Attachments []*Attachment `json:"attachments"`
 		SharedFiles []*SharedFile `json:"sharedFiles"`
@@ -50,11 +56,12 @@ func (w *Webhook) UnmarshalJSON(data []byte) error {
 	}
 
 	raw := struct {
-		ID          int       `json:"id"`
-		Project     *Project  `json:"project"`
-		Issue       *RawIssue `json:"content"`
-		CreatedUser *User     `json:"createdUser"`
-		Created     time.Time `json:"created"`
+		ID          int             `json:"id"`
+		Project     *Project        `json:"project"`
+		Type        ActivityType    `json:"type"`
+		Content     json.RawMessage `json:"content"`
+		CreatedUser *User           `json:"createdUser"`
+		Created     time.Time       `json:"created"`
 	}{}
 
 	err := json.Unmarshal(data, &raw)
@@ -62,42 +69,62 @@ func (w *Webhook) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	content, err := decodeActivityContent(raw.Type, raw.Content)
+	if err != nil {
+		return err
+	}
+
+	// only issue events have the issue as content. The content of the others
+	// has different shapes, e.g. a string comment, so it is not decoded as an issue
+	rawIssue := &RawIssue{}
+	if raw.Type.isIssue() && len(raw.Content) > 0 {
+		err = json.Unmarshal(raw.Content, &rawIssue)
+		if err != nil {
+			return err
+		}
+		if rawIssue == nil {
+			rawIssue = &RawIssue{}
+		}
+	}
+
 	issue := Issue{
-		ID:             raw.Issue.ID,
-		ProjectID:      raw.Issue.ProjectID,
-		IssueKey:       raw.Issue.IssueKey,
-		KeyID:          raw.Issue.KeyID,
-		IssueType:      raw.Issue.IssueType,
-		Summary:        raw.Issue.Summary,
-		Description:    raw.Issue.Description,
-		Resolution:     raw.Issue.Resolution,
-		Priority:       raw.Issue.Priority,
-		Status:         raw.Issue.Status.Name,
-		Assignee:       raw.Issue.Assignee,
-		Categories:     raw.Issue.Categories,
-		Versions:       raw.Issue.Versions,
-		Milestones:     raw.Issue.Milestones,
-		StartDate:      time.Time(raw.Issue.StartDate),
-		DueDate:        time.Time(raw.Issue.DueDate),
-		EstimatedHours: raw.Issue.EstimatedHours,
-		ActualHours:    raw.Issue.ActualHours,
-		ParentIssueID:  raw.Issue.ParentIssueID,
-		CreatedUser:    raw.Issue.CreatedUser,
-		Created:        raw.Issue.Created,
-		UpdatedUser:    raw.Issue.UpdatedUser,
-		Updated:        raw.Issue.Updated,
+		ID:             rawIssue.ID,
+		ProjectID:      rawIssue.ProjectID,
+		IssueKey:       rawIssue.IssueKey,
+		KeyID:          rawIssue.KeyID,
+		IssueType:      rawIssue.IssueType,
+		Summary:        rawIssue.Summary,
+		Description:    rawIssue.Description,
+		Resolution:     rawIssue.Resolution,
+		Priority:       rawIssue.Priority,
+		Status:         rawIssue.Status.Name,
+		Assignee:       rawIssue.Assignee,
+		Categories:     rawIssue.Categories,
+		Versions:       rawIssue.Versions,
+		Milestones:     rawIssue.Milestones,
+		StartDate:      time.Time(rawIssue.StartDate),
+		DueDate:        time.Time(rawIssue.DueDate),
+		EstimatedHours: rawIssue.EstimatedHours,
+		ActualHours:    rawIssue.ActualHours,
+		ParentIssueID:  rawIssue.ParentIssueID,
+		CreatedUser:    rawIssue.CreatedUser,
+		Created:        rawIssue.Created,
+		UpdatedUser:    rawIssue.UpdatedUser,
+		Updated:        rawIssue.Updated,
 		// CustomFields:   customFields,
-		Attachments: raw.Issue.Attachments,
-		SharedFiles: raw.Issue.SharedFiles,
-		Stars:       raw.Issue.Stars,
-		Comment:     raw.Issue.Comment,
+		Attachments: rawIssue.Attachments,
+		SharedFiles: rawIssue.SharedFiles,
+		Stars:       rawIssue.Stars,
+		Comment:     rawIssue.Comment,
 	}
 
 	w.ID = raw.ID
 	w.Project = raw.Project
+	w.Type = raw.Type
 	w.Issue = &issue
 	w.CreatedUser = raw.CreatedUser
 	w.Created = raw.Created
+	w.Content = content
 
 	return nil
 }
@@ -144,15 +171,3 @@ func (w *Webhook) UnmarshalJSON(data []byte) error {
 // 	r.Value = f
 // 	return nil
 // }
-
-type Project struct {
-	ID                                int    `json:"id"`
-	Key                               string `json:"projectKey"`
-	Name                              string `json:"name"`
-	ChartEnabled                      bool   `json:"chartEnabled"`
-	SubtaskingEnabled                 bool   `json:"subtaskingEnabled"`
-	ProjectLeaderCanEditProjectLeader bool   `json:"projectLeaderCanEditProjectLeader"`
-	UseWikiTreeView                   bool   `json:"useWikiTreeView"`
-	TextFormattingRule                string `json:"textFormattingRule"`
-	Archived                          bool   `json:"archived"`
-}
@@ -0,0 +1,298 @@
+package backlog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Wiki struct {
+	ID          int           `json:"id,omitempty"`
+	ProjectID   int           `json:"projectId,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Content     string        `json:"content,omitempty"` // empty in ListWikis
+	Tags        []*WikiTag    `json:"tags,omitempty"`
+	Attachments []*Attachment `json:"attachments,omitempty"`
+	SharedFiles []*SharedFile `json:"sharedFiles,omitempty"`
+	Stars       []*Star       `json:"stars,omitempty"`
+	CreatedUser *User         `json:"createdUser,omitempty"`
+	Created     time.Time     `json:"created,omitempty"`
+	UpdatedUser *User         `json:"updatedUser,omitempty"`
+	Updated     time.Time     `json:"updated,omitempty"`
+}
+
+type WikiTag struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// WikiHistory is a past version of a wiki page.
+type WikiHistory struct {
+	PageID      int       `json:"pageId,omitempty"`
+	Version     int       `json:"version,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	CreatedUser *User     `json:"createdUser,omitempty"`
+	Created     time.Time `json:"created,omitempty"`
+}
+
+// ListWikis lists the wiki pages of the project without their contents.
+// If keyword is not empty, only the pages that contain it are listed.
+func (repo *Repository) ListWikis(projectIDOrKey string, keyword string) ([]*Wiki, error) {
+	query := url.Values{"projectIdOrKey": {projectIDOrKey}}
+	if keyword != "" {
+		query.Set("keyword", keyword)
+	}
+
+	var ws []*Wiki
+	err := repo.get("api/v2/wikis", query, &ws)
+	if err != nil {
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func (repo *Repository) CountWikis(projectIDOrKey string) (int, error) {
+	query := url.Values{"projectIdOrKey": {projectIDOrKey}}
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	err := repo.get("api/v2/wikis/count", query, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+func (repo *Repository) ListWikiTags(projectIDOrKey string) ([]*WikiTag, error) {
+	query := url.Values{"projectIdOrKey": {projectIDOrKey}}
+
+	var tags []*WikiTag
+	err := repo.get("api/v2/wikis/tags", query, &tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (repo *Repository) FindWiki(id int) (*Wiki, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d", id)
+
+	var w Wiki
+	err := repo.get(url, nil, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// CreateWiki creates a wiki page in the project of wiki.ProjectID.
+// If mailNotify is true, the project members are notified by email.
+func (repo *Repository) CreateWiki(wiki *Wiki, mailNotify bool) (*Wiki, error) {
+	params := url.Values{
+		"projectId":  {strconv.Itoa(wiki.ProjectID)},
+		"name":       {wiki.Name},
+		"content":    {wiki.Content},
+		"mailNotify": {strconv.FormatBool(mailNotify)},
+	}
+
+	var created Wiki
+	err := repo.post("api/v2/wikis", params, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateWiki updates the name and the content of the wiki page with the same ID as wiki.
+func (repo *Repository) UpdateWiki(wiki *Wiki, mailNotify bool) (*Wiki, error) {
+	params := url.Values{
+		"name":       {wiki.Name},
+		"content":    {wiki.Content},
+		"mailNotify": {strconv.FormatBool(mailNotify)},
+	}
+	url := fmt.Sprintf("api/v2/wikis/%d", wiki.ID)
+
+	var updated Wiki
+	err := repo.patch(url, params, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (repo *Repository) DeleteWiki(id int, mailNotify bool) (*Wiki, error) {
+	params := url.Values{"mailNotify": {strconv.FormatBool(mailNotify)}}
+	url := fmt.Sprintf("api/v2/wikis/%d", id)
+
+	var deleted Wiki
+	err := repo.delete(url, params, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+//+wiki attachments
+
+func (repo *Repository) ListWikiAttachments(wikiID int) ([]*Attachment, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d/attachments", wikiID)
+
+	var as []*Attachment
+	err := repo.get(url, nil, &as)
+	if err != nil {
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// AttachToWiki attaches files uploaded by UploadAttachment to a wiki page.
+func (repo *Repository) AttachToWiki(wikiID int, attachmentIDs []int) ([]*Attachment, error) {
+	params := url.Values{}
+	for _, id := range attachmentIDs {
+		params.Add("attachmentId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("api/v2/wikis/%d/attachments", wikiID)
+
+	var as []*Attachment
+	err := repo.post(url, params, &as)
+	if err != nil {
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// DownloadWikiAttachment downloads a file attached to a wiki page.
+// Close the returned File after reading.
+func (repo *Repository) DownloadWikiAttachment(wikiID int, id int) (*File, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d/attachments/%d", wikiID, id)
+	return repo.download(url, nil)
+}
+
+func (repo *Repository) DeleteWikiAttachment(wikiID int, id int) (*Attachment, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d/attachments/%d", wikiID, id)
+
+	var deleted Attachment
+	err := repo.delete(url, nil, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+//-wiki attachments
+
+//+wiki shared files
+
+func (repo *Repository) ListWikiSharedFiles(wikiID int) ([]*SharedFile, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d/sharedFiles", wikiID)
+
+	var fs []*SharedFile
+	err := repo.get(url, nil, &fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+// LinkWikiSharedFiles links shared files to a wiki page and returns the linked files.
+func (repo *Repository) LinkWikiSharedFiles(wikiID int, fileIDs []int) ([]*SharedFile, error) {
+	params := url.Values{}
+	for _, id := range fileIDs {
+		params.Add("fileId[]", strconv.Itoa(id))
+	}
+	url := fmt.Sprintf("api/v2/wikis/%d/sharedFiles", wikiID)
+
+	var fs []*SharedFile
+	err := repo.post(url, params, &fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+func (repo *Repository) UnlinkWikiSharedFile(wikiID int, fileID int) (*SharedFile, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d/sharedFiles/%d", wikiID, fileID)
+
+	var f SharedFile
+	err := repo.delete(url, nil, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+//-wiki shared files
+
+//+WikiHistoryQuery
+
+type WikiHistoryQuery url.Values
+
+func NewWikiHistoryQuery() WikiHistoryQuery {
+	q := WikiHistoryQuery{}
+	return q
+}
+
+func (q WikiHistoryQuery) SetMinID(id int) {
+	url.Values(q).Set("minId", strconv.Itoa(id))
+	return
+}
+
+func (q WikiHistoryQuery) SetMaxID(id int) {
+	url.Values(q).Set("maxId", strconv.Itoa(id))
+	return
+}
+
+// SetCount sets the number of histories to list, 1 to 100.
+func (q WikiHistoryQuery) SetCount(count int) {
+	url.Values(q).Set("count", strconv.Itoa(count))
+	return
+}
+
+// SetOrder sets "asc" or "desc".
+func (q WikiHistoryQuery) SetOrder(order string) {
+	url.Values(q).Set("order", order)
+	return
+}
+
+//-WikiHistoryQuery
+
+func (repo *Repository) ListWikiHistory(wikiID int, q WikiHistoryQuery) ([]*WikiHistory, error) {
+	query := url.Values(q)
+	url := fmt.Sprintf("api/v2/wikis/%d/history", wikiID)
+
+	var hs []*WikiHistory
+	err := repo.get(url, query, &hs)
+	if err != nil {
+		return nil, err
+	}
+
+	return hs, nil
+}
+
+func (repo *Repository) ListWikiStars(wikiID int) ([]*Star, error) {
+	url := fmt.Sprintf("api/v2/wikis/%d/stars", wikiID)
+
+	var stars []*Star
+	err := repo.get(url, nil, &stars)
+	if err != nil {
+		return nil, err
+	}
+
+	return stars, nil
+}
@@ -0,0 +1,232 @@
+package backlog
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WikiSync mirrors a local directory tree of Markdown files into the wiki of a project.
+// A file "runbooks/db.md" becomes the page Prefix+"runbooks/db".
+//
+//	s := &backlog.WikiSync{Repository: repo, ProjectID: 1, Dir: "docs", Prefix: "docs/"}
+//	plan, err := s.Plan()
+//	fmt.Print(plan) // dry run
+//	err = s.Apply(plan)
+type WikiSync struct {
+	Repository *Repository
+	ProjectID  int
+	Dir        string
+
+	// Prefix is the directory of the pages, prepended to page names.
+	// "/" is added if it does not end with one, so "docs" never matches "docs-archive/…".
+	// Only the pages under Prefix are compared with the files, so pages outside of it
+	// are never deleted. An empty Prefix matches every page in the project.
+	Prefix string
+
+	// Delete deletes the pages with Prefix that have no corresponding file.
+	// It requires a non-empty Prefix, so that pages such as Home are not deleted.
+	Delete bool
+
+	MailNotify bool
+}
+
+type WikiSyncAction int
+
+const (
+	WikiSyncCreate WikiSyncAction = iota + 1
+	WikiSyncUpdate
+	WikiSyncDelete
+)
+
+func (a WikiSyncAction) String() string {
+	switch a {
+	case WikiSyncCreate:
+		return "create"
+	case WikiSyncUpdate:
+		return "update"
+	case WikiSyncDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// WikiSyncStep is a change WikiSync makes to a page.
+type WikiSyncStep struct {
+	Action  WikiSyncAction
+	Name    string // page name
+	File    string // path relative to Dir. empty for WikiSyncDelete
+	WikiID  int    // 0 for WikiSyncCreate
+	Content string
+}
+
+type WikiSyncPlan []*WikiSyncStep
+
+// String returns the plan as a human readable list, one step per line.
+func (p WikiSyncPlan) String() string {
+	var b strings.Builder
+	for _, step := range p {
+		switch step.Action {
+		case WikiSyncCreate:
+			fmt.Fprintf(&b, "+ %s (%s)\n", step.Name, step.File)
+		case WikiSyncUpdate:
+			fmt.Fprintf(&b, "~ %s (%s)\n", step.Name, step.File)
+		case WikiSyncDelete:
+			fmt.Fprintf(&b, "- %s\n", step.Name)
+		}
+	}
+	return b.String()
+}
+
+// Plan compares the files with the wiki pages and returns the changes
+// Apply would make, without changing anything.
+// It returns an error if Delete is set without Prefix.
+func (s *WikiSync) Plan() (WikiSyncPlan, error) {
+	if s.Delete && s.Prefix == "" {
+		return nil, errors.New("delete requires a prefix, or every page without a file would be deleted")
+	}
+
+	files, err := s.readFiles()
+	if err != nil {
+		return nil, errors.Wrap(err, "read markdown files failed")
+	}
+
+	wikis, err := s.Repository.ListWikis(strconv.Itoa(s.ProjectID), "")
+	if err != nil {
+		return nil, errors.Wrap(err, "list wikis failed")
+	}
+
+	prefix := s.prefix()
+	pages := make(map[string]*Wiki)
+	for _, w := range wikis {
+		if strings.HasPrefix(w.Name, prefix) {
+			pages[w.Name] = w
+		}
+	}
+
+	var plan WikiSyncPlan
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := files[name]
+
+		page, ok := pages[name]
+		if !ok {
+			plan = append(plan, &WikiSyncStep{Action: WikiSyncCreate, Name: name, File: f.path, Content: f.content})
+			continue
+		}
+
+		// ListWikis does not return contents
+		w, err := s.Repository.FindWiki(page.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("find wiki '%s' failed", name))
+		}
+		if normalizeNewlines(w.Content) != normalizeNewlines(f.content) {
+			plan = append(plan, &WikiSyncStep{Action: WikiSyncUpdate, Name: name, File: f.path, WikiID: w.ID, Content: f.content})
+		}
+	}
+
+	if s.Delete {
+		var deleted WikiSyncPlan
+		for name, page := range pages {
+			if _, ok := files[name]; !ok {
+				deleted = append(deleted, &WikiSyncStep{Action: WikiSyncDelete, Name: name, WikiID: page.ID})
+			}
+		}
+		sort.Slice(deleted, func(i, j int) bool { return deleted[i].Name < deleted[j].Name })
+		plan = append(plan, deleted...)
+	}
+
+	return plan, nil
+}
+
+// Apply makes the changes of plan. It stops at the first error.
+func (s *WikiSync) Apply(plan WikiSyncPlan) error {
+	for _, step := range plan {
+		var err error
+		switch step.Action {
+		case WikiSyncCreate:
+			_, err = s.Repository.CreateWiki(&Wiki{ProjectID: s.ProjectID, Name: step.Name, Content: step.Content}, s.MailNotify)
+		case WikiSyncUpdate:
+			_, err = s.Repository.UpdateWiki(&Wiki{ID: step.WikiID, Name: step.Name, Content: step.Content}, s.MailNotify)
+		case WikiSyncDelete:
+			_, err = s.Repository.DeleteWiki(step.WikiID, s.MailNotify)
+		}
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("%s wiki '%s' failed", step.Action, step.Name))
+		}
+	}
+
+	return nil
+}
+
+// Sync plans and applies the changes. If dryRun is true, nothing is changed.
+func (s *WikiSync) Sync(dryRun bool) (WikiSyncPlan, error) {
+	plan, err := s.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return plan, nil
+	}
+
+	return plan, s.Apply(plan)
+}
+
+// prefix returns Prefix ending with "/", or "" if Prefix is empty.
+func (s *WikiSync) prefix() string {
+	if s.Prefix == "" || strings.HasSuffix(s.Prefix, "/") {
+		return s.Prefix
+	}
+	return s.Prefix + "/"
+}
+
+type wikiSyncFile struct {
+	path    string
+	content string
+}
+
+// readFiles reads the Markdown files under Dir keyed by page name.
+func (s *WikiSync) readFiles() (map[string]*wikiSyncFile, error) {
+	fsys := os.DirFS(s.Dir)
+	files := make(map[string]*wikiSyncFile)
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != ".md" {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		name := s.prefix() + strings.TrimSuffix(p, ".md")
+		files[name] = &wikiSyncFile{path: p, content: string(data)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func normalizeNewlines(s string) string {
+	return strings.TrimRight(strings.Replace(s, "\r\n", "\n", -1), "\n")
+}
@@ -0,0 +1,78 @@
+package backlog
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWikiSyncPlanRequiresPrefixToDelete(t *testing.T) {
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	s := &WikiSync{Repository: repo, ProjectID: 1, Dir: t.TempDir(), Delete: true}
+	if _, err := s.Plan(); err == nil {
+		t.Error("Plan succeeded with Delete and no Prefix")
+	}
+}
+
+func TestWikiSyncPlan(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.md":        "# Docs\n",
+		"runbooks/db.md":  "line1\nline2\n",
+		"runbooks/web.md": "new\n",
+		"notes.txt":       "not markdown",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pages := map[string]*Wiki{
+		"/api/v2/wikis/2": {ID: 2, Name: "docs/runbooks/db", Content: "line1\r\nline2"},
+		"/api/v2/wikis/3": {ID: 3, Name: "docs/runbooks/web", Content: "old"},
+	}
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v2/wikis" {
+			w.Write([]byte(`[
+				{"id": 1, "name": "Home"},
+				{"id": 2, "name": "docs/runbooks/db"},
+				{"id": 3, "name": "docs/runbooks/web"},
+				{"id": 4, "name": "docs/old"},
+				{"id": 5, "name": "docs-archive/old"}
+			]`))
+			return
+		}
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	s := &WikiSync{Repository: repo, ProjectID: 1, Dir: dir, Prefix: "docs", Delete: true}
+	plan, err := s.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := WikiSyncPlan{
+		{Action: WikiSyncCreate, Name: "docs/index", File: "index.md", Content: "# Docs\n"},
+		{Action: WikiSyncUpdate, Name: "docs/runbooks/web", File: "runbooks/web.md", WikiID: 3, Content: "new\n"},
+		{Action: WikiSyncDelete, Name: "docs/old", WikiID: 4},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("plan =\n%s\nwant\n%s", plan, want)
+	}
+}